@@ -0,0 +1,158 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Limits GitHub enforces on check run annotations
+const (
+	// MaxAnnotationsPerRequest is the most annotations a single check run update accepts
+	MaxAnnotationsPerRequest = 50
+	// DefaultMaxAnnotations is the total number of annotations UpdateCheckRunAnnotations reports unless told otherwise
+	DefaultMaxAnnotations = 1000
+
+	maxAnnotationTitle = 255
+	maxAnnotationBytes = 64 * 1024
+)
+
+// AnnotationOptions tunes UpdateCheckRunAnnotations
+type AnnotationOptions struct {
+	// MaxAnnotations caps the annotations reported across all requests, DefaultMaxAnnotations when zero
+	MaxAnnotations int
+	// PerRequest is the batch size, MaxAnnotationsPerRequest when zero or larger
+	PerRequest int
+}
+
+// AnnotationReport summarizes what UpdateCheckRunAnnotations sent
+type AnnotationReport struct {
+	Sent     int
+	Requests int
+	// Dropped counts the annotations left out to stay within the limits, by level
+	Dropped map[AnnotationLevel]int
+}
+
+// DroppedTotal returns how many annotations were left out
+func (r *AnnotationReport) DroppedTotal() int {
+	total := 0
+	for _, n := range r.Dropped {
+		total += n
+	}
+	return total
+}
+
+// UpdateCheckRunAnnotations attaches any number of annotations to a check run, spreading them across as many updates
+// as needed. The most severe annotations are sent first, annotations beyond the limit are dropped and counted in
+// the output summary instead of failing the update.
+func (c *Client) UpdateCheckRunAnnotations(ctx context.Context, owner, repo string, id int, output Output, annotations []Annotation, opts *AnnotationOptions) (*AnnotationReport, error) {
+	limit, perRequest := DefaultMaxAnnotations, MaxAnnotationsPerRequest
+	if opts != nil {
+		if opts.MaxAnnotations > 0 {
+			limit = opts.MaxAnnotations
+		}
+		if opts.PerRequest > 0 && opts.PerRequest < perRequest {
+			perRequest = opts.PerRequest
+		}
+	}
+
+	sorted := make([]Annotation, len(annotations))
+	for i, a := range annotations {
+		sorted[i] = clampAnnotation(a)
+	}
+	sortAnnotations(sorted)
+
+	report := &AnnotationReport{Dropped: map[AnnotationLevel]int{}}
+	if len(sorted) > limit {
+		for _, a := range sorted[limit:] {
+			report.Dropped[a.AnnotationLevel]++
+		}
+		sorted = sorted[:limit]
+		output.Summary += droppedSummary(report)
+	}
+
+	// a single update still sends the output when there is nothing to annotate
+	for start := 0; start == 0 || start < len(sorted); start += perRequest {
+		end := start + perRequest
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+
+		batch := output
+		batch.Annotations = sorted[start:end]
+		if _, _, err := c.UpdateCheckRun(ctx, owner, repo, id, CheckRun{Output: batch}); err != nil {
+			return report, fmt.Errorf("ghclient: annotations %d-%d of %d: %w", start+1, end, len(sorted), err)
+		}
+		report.Requests++
+		report.Sent += end - start
+	}
+	return report, nil
+}
+
+// severity orders annotation levels, higher is more severe
+func severity(l AnnotationLevel) int {
+	switch l {
+	case AnnotationLevelFailure:
+		return 2
+	case AnnotationLevelWarning:
+		return 1
+	}
+	return 0
+}
+
+// sortAnnotations orders by severity, then by file and line so batches stay stable between runs
+func sortAnnotations(annotations []Annotation) {
+	sort.SliceStable(annotations, func(i, j int) bool {
+		a, b := annotations[i], annotations[j]
+		if severity(a.AnnotationLevel) != severity(b.AnnotationLevel) {
+			return severity(a.AnnotationLevel) > severity(b.AnnotationLevel)
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.StartLine < b.StartLine
+	})
+}
+
+// clampAnnotation fixes the fields GitHub would reject the whole request for
+func clampAnnotation(a Annotation) Annotation {
+	if !a.AnnotationLevel.IsValid() {
+		a.AnnotationLevel = AnnotationLevelNotice
+	}
+	if a.EndLine < a.StartLine {
+		a.EndLine = a.StartLine
+	}
+	// columns are only accepted on single line annotations
+	if a.StartLine != a.EndLine {
+		a.StartColumn, a.EndColumn = nil, nil
+	}
+	a.Title = truncateRunes(a.Title, maxAnnotationTitle)
+	a.Message = truncateBytes(a.Message, maxAnnotationBytes)
+	a.RawDetails = truncateBytes(a.RawDetails, maxAnnotationBytes)
+	return a
+}
+
+func droppedSummary(r *AnnotationReport) string {
+	return fmt.Sprintf("\n\n%d annotations were not reported to stay within GitHub's limits (%d failures, %d warnings, %d notices).",
+		r.DroppedTotal(), r.Dropped[AnnotationLevelFailure], r.Dropped[AnnotationLevelWarning], r.Dropped[AnnotationLevelNotice])
+}
+
+// truncateRunes shortens s to at most n characters
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// truncateBytes shortens s to at most n bytes without splitting a character
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,194 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+// annotationServer records the output of every PATCH of check run 1, failing the request numbered failAt
+func annotationServer(t *testing.T, failAt int) (*Client, func() []outputRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var outputs []outputRequest
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/check-runs/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("method = %s", r.Method)
+		}
+		var body checkRunRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(outputs)+1 == failAt {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message":"Validation Failed"}`)
+			return
+		}
+		if body.Output != nil {
+			outputs = append(outputs, *body.Output)
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+	return c, func() []outputRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return outputs
+	}
+}
+
+func testAnnotations(failures, warnings, notices int) []Annotation {
+	counts := map[AnnotationLevel]int{AnnotationLevelFailure: failures, AnnotationLevelWarning: warnings, AnnotationLevelNotice: notices}
+	var annotations []Annotation
+	// interleaved, least severe first, so sorting has work to do
+	for len(annotations) < failures+warnings+notices {
+		for _, level := range []AnnotationLevel{AnnotationLevelNotice, AnnotationLevelWarning, AnnotationLevelFailure} {
+			if counts[level] == 0 {
+				continue
+			}
+			counts[level]--
+			path := fmt.Sprintf("f%03d.go", len(annotations))
+			annotations = append(annotations, Annotation{Path: path, StartLine: 1, EndLine: 1, AnnotationLevel: level, Message: "m"})
+		}
+	}
+	return annotations
+}
+
+func TestUpdateCheckRunAnnotations(t *testing.T) {
+	c, outputs := annotationServer(t, 0)
+	annotations := testAnnotations(30, 40, 50)
+
+	report, err := c.UpdateCheckRunAnnotations(context.Background(), "o", "r", 1, Output{Title: "lint", Summary: "Found problems."},
+		annotations, &AnnotationOptions{MaxAnnotations: 110})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Sent != 110 || report.Requests != 3 || report.DroppedTotal() != 10 || report.Dropped[AnnotationLevelNotice] != 10 {
+		t.Errorf("report = %+v", report)
+	}
+
+	sent := outputs()
+	if len(sent) != 3 {
+		t.Fatalf("%d PATCH requests, want 3", len(sent))
+	}
+	var all []Annotation
+	for i, output := range sent {
+		if n := len(output.Annotations); n > MaxAnnotationsPerRequest || n == 0 {
+			t.Errorf("request %d sent %d annotations", i, n)
+		}
+		if output.Title != "lint" {
+			t.Errorf("request %d title = %q", i, output.Title)
+		}
+		want := "Found problems.\n\n10 annotations were not reported to stay within GitHub's limits (0 failures, 0 warnings, 10 notices)."
+		if output.Summary != want {
+			t.Errorf("request %d summary = %q", i, output.Summary)
+		}
+		all = append(all, output.Annotations...)
+	}
+	if len(all) != 110 {
+		t.Fatalf("sent %d annotations, want 110", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if severity(all[i].AnnotationLevel) > severity(all[i-1].AnnotationLevel) {
+			t.Fatalf("annotation %d (%s) sent after a less severe one", i, all[i].AnnotationLevel)
+		}
+	}
+	if all[29].AnnotationLevel != AnnotationLevelFailure || all[30].AnnotationLevel != AnnotationLevelWarning {
+		t.Error("failures are not sent first")
+	}
+}
+
+func TestUpdateCheckRunAnnotationsPerRequest(t *testing.T) {
+	c, outputs := annotationServer(t, 0)
+	report, err := c.UpdateCheckRunAnnotations(context.Background(), "o", "r", 1, Output{Title: "lint", Summary: "s"},
+		testAnnotations(0, 0, 25), &AnnotationOptions{PerRequest: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Requests != 3 || report.DroppedTotal() != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	for i, output := range outputs() {
+		if want := []int{10, 10, 5}[i]; len(output.Annotations) != want {
+			t.Errorf("request %d sent %d annotations, want %d", i, len(output.Annotations), want)
+		}
+		if output.Summary != "s" {
+			t.Errorf("request %d summary = %q", i, output.Summary)
+		}
+	}
+}
+
+func TestUpdateCheckRunAnnotationsEmpty(t *testing.T) {
+	c, outputs := annotationServer(t, 0)
+	report, err := c.UpdateCheckRunAnnotations(context.Background(), "o", "r", 1, Output{Title: "lint", Summary: "clean"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Requests != 1 || report.Sent != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	if sent := outputs(); len(sent) != 1 || sent[0].Summary != "clean" {
+		t.Errorf("outputs = %+v", sent)
+	}
+}
+
+func TestUpdateCheckRunAnnotationsError(t *testing.T) {
+	c, _ := annotationServer(t, 2)
+	report, err := c.UpdateCheckRunAnnotations(context.Background(), "o", "r", 1, Output{Title: "lint", Summary: "s"},
+		testAnnotations(0, 0, 120), nil)
+	if err == nil || !strings.Contains(err.Error(), "annotations 51-100 of 120") {
+		t.Errorf("err = %v", err)
+	}
+	if report.Requests != 1 || report.Sent != 50 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestClampAnnotation(t *testing.T) {
+	col := func(n int) *int { return &n }
+	tests := []struct {
+		name string
+		in   Annotation
+		want Annotation
+	}{
+		{"valid", Annotation{StartLine: 3, EndLine: 3, StartColumn: col(1), EndColumn: col(4), AnnotationLevel: AnnotationLevelWarning},
+			Annotation{StartLine: 3, EndLine: 3, StartColumn: col(1), EndColumn: col(4), AnnotationLevel: AnnotationLevelWarning}},
+		{"unknown level", Annotation{StartLine: 1, EndLine: 1, AnnotationLevel: "error"},
+			Annotation{StartLine: 1, EndLine: 1, AnnotationLevel: AnnotationLevelNotice}},
+		{"end before start", Annotation{StartLine: 5, EndLine: 2, AnnotationLevel: AnnotationLevelFailure},
+			Annotation{StartLine: 5, EndLine: 5, AnnotationLevel: AnnotationLevelFailure}},
+		{"multi-line clears columns", Annotation{StartLine: 1, EndLine: 4, StartColumn: col(2), EndColumn: col(8), AnnotationLevel: AnnotationLevelNotice},
+			Annotation{StartLine: 1, EndLine: 4, AnnotationLevel: AnnotationLevelNotice}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clampAnnotation(tt.in)
+			if got.StartLine != tt.want.StartLine || got.EndLine != tt.want.EndLine || got.AnnotationLevel != tt.want.AnnotationLevel ||
+				!equalPtr(got.StartColumn, tt.want.StartColumn, func(a, b int) bool { return a == b }) ||
+				!equalPtr(got.EndColumn, tt.want.EndColumn, func(a, b int) bool { return a == b }) {
+				t.Errorf("clampAnnotation() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	long := clampAnnotation(Annotation{
+		Title:      strings.Repeat("é", 300),
+		Message:    strings.Repeat("ü", 40*1024),
+		RawDetails: "x" + strings.Repeat("ü", 40*1024),
+	})
+	if n := utf8.RuneCountInString(long.Title); n != maxAnnotationTitle {
+		t.Errorf("title has %d characters", n)
+	}
+	for name, s := range map[string]string{"message": long.Message, "raw details": long.RawDetails} {
+		if len(s) > maxAnnotationBytes || len(s) < maxAnnotationBytes-1 || !utf8.ValidString(s) {
+			t.Errorf("%s is %d bytes, valid UTF-8 %v", name, len(s), utf8.ValidString(s))
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ghclient
+
+import (
+	"net/http"
+)
+
+// TokenTransport authenticates requests with a personal access token or an OAuth access token
+type TokenTransport struct {
+	Token string
+	// Base is the transport that sends the request, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// RoundTrip sets the Authorization header and sends the request
+func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return base(t.Base).RoundTrip(withAuthorization(req, "Bearer "+t.Token))
+}
+
+// Client returns an http.Client using the transport
+func (t *TokenTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// AppTransport authenticates requests as the GitHub App itself using a signed JWT, as required for the /app endpoints
+type AppTransport struct {
+	Source *AppTokenSource
+	// Base is the transport that sends the request, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// RoundTrip signs the request with the app JWT, retrying with the next private key when GitHub rejects it
+func (t *AppTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for {
+		jwt, key, err := t.Source.Token()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := base(t.Base).RoundTrip(withAuthorization(req, "Bearer "+jwt))
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || !canReplay(req) || !t.Source.Fallback(key) {
+			return resp, err
+		}
+		resp.Body.Close()
+		if req, err = replay(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Client returns an http.Client using the transport
+func (t *AppTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// InstallationTransport authenticates requests as an app installation with a cached installation access token
+type InstallationTransport struct {
+	Source         *InstallationTokenSource
+	InstallationID int
+	// Options scopes the token down, nil keeps every repository and permission of the installation
+	Options *TokenOptions
+	// Base is the transport that sends the request, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// NewInstallationTransport returns a transport acting as the installation that sent an event
+func NewInstallationTransport(source *InstallationTokenSource, installation Installation) *InstallationTransport {
+	return &InstallationTransport{Source: source, InstallationID: installation.ID}
+}
+
+// RoundTrip sets the installation token, minting a fresh token once when GitHub rejects the cached one
+func (t *InstallationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retried := false; ; retried = true {
+		token, err := t.Source.Token(req.Context(), t.InstallationID, t.Options)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := base(t.Base).RoundTrip(withAuthorization(req, "token "+token.Token))
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || retried || !canReplay(req) {
+			return resp, err
+		}
+		resp.Body.Close()
+		t.Source.Invalidate(t.InstallationID, t.Options)
+		if req, err = replay(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Client returns an http.Client using the transport
+func (t *InstallationTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func base(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		return http.DefaultTransport
+	}
+	return rt
+}
+
+// withAuthorization clones the request, a RoundTripper must not modify the request it was given
+func withAuthorization(req *http.Request, value string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", value)
+	return clone
+}
+
+// canReplay reports whether the request body can be sent again
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// replay returns a copy of the request with a fresh body
+func replay(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
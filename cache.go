@@ -0,0 +1,303 @@
+package ghclient
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FromCacheHeader is set on responses CachingTransport served from its cache after GitHub answered 304 Not Modified
+const FromCacheHeader = "X-From-Cache"
+
+// Cache stores serialized responses for CachingTransport, implementations must be safe for concurrent use
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, response []byte)
+	Delete(key string)
+}
+
+// CachingTransport sends conditional requests for GET requests it has a response with an ETag or Last-Modified
+// for, and answers from the cache when GitHub replies 304 Not Modified, which does not count against the rate limit.
+//
+// Use it as the Base of an authenticating transport so responses are cached per credential, one installation
+// must not be answered with what another was allowed to see.
+type CachingTransport struct {
+	Cache Cache
+	// Base is the transport that sends the request, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// NewCachingTransport returns a transport caching in cache, base defaults to http.DefaultTransport
+func NewCachingTransport(cache Cache, base http.RoundTripper) *CachingTransport {
+	return &CachingTransport{Cache: cache, Base: base}
+}
+
+// RoundTrip sends the request, conditionally when a cached response exists
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return base(t.Base).RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached := t.cached(key, req)
+	if cached != nil {
+		clone := req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			clone.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			clone.Header.Set("If-Modified-Since", modified)
+		}
+		req = clone
+	}
+
+	resp, err := base(t.Base).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		// the 304 carries the current rate limit and a possibly new ETag
+		for name, values := range resp.Header {
+			cached.Header[name] = values
+		}
+		cached.Header.Set(FromCacheHeader, "1")
+		cached.Request = req
+		return cached, nil
+	}
+
+	if resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "") {
+		dump, err := httputil.DumpResponse(resp, true)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		t.Cache.Set(key, dump)
+	} else if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		t.Cache.Delete(key)
+	}
+	return resp, nil
+}
+
+// cached returns the stored response for key, dropping entries that can no longer be read
+func (t *CachingTransport) cached(key string, req *http.Request) *http.Response {
+	b, ok := t.Cache.Get(key)
+	if !ok {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+	if err != nil {
+		t.Cache.Delete(key)
+		return nil
+	}
+	return resp
+}
+
+// Client returns an http.Client using the transport
+func (t *CachingTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// cacheKey identifies a response by URL, the headers GitHub varies on and a hash of the credentials
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + " " + req.Header.Get("Accept") + " " + req.Header.Get("X-GitHub-Api-Version") + " " + hex.EncodeToString(auth[:8])
+}
+
+// MemoryCache keeps responses in memory, evicting the least recently used ones beyond MaxEntries. The zero value
+// is ready to use.
+type MemoryCache struct {
+	// MaxEntries bounds the number of responses kept, zero keeps everything
+	MaxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key      string
+	response []byte
+}
+
+// NewMemoryCache returns a cache keeping up to maxEntries responses, zero keeps everything
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{MaxEntries: maxEntries}
+}
+
+// init allocates the LRU list and index on first use, c.mu must be held
+func (c *MemoryCache) init() {
+	if c.entries == nil {
+		c.order, c.entries = list.New(), map[string]*list.Element{}
+	}
+}
+
+// Get returns the response stored under key
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*memoryEntry).response, true
+}
+
+// Set stores response under key
+func (c *MemoryCache) Set(key string, response []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryEntry).response = response
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&memoryEntry{key: key, response: response})
+	for c.MaxEntries > 0 && c.order.Len() > c.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Delete removes the response stored under key
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// Defaults of NewDiskCache. Responses are cached per credential and installation tokens change hourly, so without
+// bounds the entries of expired tokens would pile up.
+const (
+	DefaultDiskCacheMaxAge   = 24 * time.Hour
+	DefaultDiskCacheMaxBytes = 64 << 20
+)
+
+// diskCachePruneInterval is how often Set looks for entries beyond the bounds
+const diskCachePruneInterval = time.Minute
+
+// DiskCache keeps responses as files in a directory, so they survive restarts of a function instance. Failures
+// to read or write are treated as cache misses.
+type DiskCache struct {
+	Dir string
+	// MaxAge drops responses not used for longer, zero keeps them forever
+	MaxAge time.Duration
+	// MaxBytes bounds the size of the directory, the least recently used responses are removed first beyond it.
+	// Zero does not bound the size. It is checked at most once a minute, so the directory can briefly grow past it.
+	MaxBytes int64
+
+	mu     sync.Mutex
+	pruned time.Time
+}
+
+// NewDiskCache returns a cache storing responses in dir, creating it when missing, bounded by DefaultDiskCacheMaxAge
+// and DefaultDiskCacheMaxBytes
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{Dir: dir, MaxAge: DefaultDiskCacheMaxAge, MaxBytes: DefaultDiskCacheMaxBytes}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the response stored under key, marking it as recently used
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.MaxAge > 0 && time.Since(info.ModTime()) > c.MaxAge {
+		os.Remove(path)
+		return nil, false
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return b, true
+}
+
+// Set stores response under key, writing to a temporary file first so readers never see a partial response
+func (c *DiskCache) Set(key string, response []byte) {
+	f, err := ioutil.TempFile(c.Dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(response)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	c.prune()
+}
+
+// Delete removes the response stored under key
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// prune removes responses older than MaxAge, then the least recently used ones until the directory fits MaxBytes
+func (c *DiskCache) prune() {
+	if c.MaxAge <= 0 && c.MaxBytes <= 0 {
+		return
+	}
+	c.mu.Lock()
+	if time.Since(c.pruned) < diskCachePruneInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.pruned = time.Now()
+	c.mu.Unlock()
+
+	files, err := ioutil.ReadDir(c.Dir)
+	if err != nil {
+		return
+	}
+	// newest first, so the files beyond MaxBytes are the least recently used
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().After(files[j].ModTime()) })
+
+	var size int64
+	for _, info := range files {
+		if info.IsDir() {
+			continue
+		}
+		size += info.Size()
+		if (c.MaxAge > 0 && time.Since(info.ModTime()) > c.MaxAge) || (c.MaxBytes > 0 && size > c.MaxBytes) {
+			os.Remove(filepath.Join(c.Dir, info.Name()))
+		}
+	}
+}
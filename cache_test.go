@@ -0,0 +1,156 @@
+package ghclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCachingTransportNotModified(t *testing.T) {
+	var requests, notModified int
+	c, mux := setup(t, NewCachingTransport(NewMemoryCache(10), nil).Client())
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(5000-requests))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id":1296269,"name":"Hello-World"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		req, _ := c.NewRequest(http.MethodGet, "repos/o/r", nil)
+		repo := &Repository{}
+		resp, err := c.Do(context.Background(), req, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.Name != "Hello-World" {
+			t.Errorf("request %d decoded %+v", i, repo)
+		}
+		if fromCache := resp.Header.Get(FromCacheHeader) == "1"; fromCache != (i == 1) {
+			t.Errorf("request %d: %s = %q", i, FromCacheHeader, resp.Header.Get(FromCacheHeader))
+		}
+		// the rate limit comes from the 304, not the cached response
+		if want := 5000 - requests; resp.Rate.Remaining != want {
+			t.Errorf("request %d: Remaining = %d, want %d", i, resp.Rate.Remaining, want)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("%d requests, %d not modified", requests, notModified)
+	}
+}
+
+func TestCachingTransportDropsGone(t *testing.T) {
+	cache := NewMemoryCache(0)
+	status := http.StatusOK
+	c, mux := setup(t, NewCachingTransport(cache, nil).Client())
+	mux.HandleFunc("/repos/o/r", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := c.NewRequest(http.MethodGet, "repos/o/r", nil)
+	if _, err := c.Do(context.Background(), req, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(cacheKey(req)); !ok {
+		t.Fatal("response was not cached")
+	}
+
+	status = http.StatusNotFound
+	req, _ = c.NewRequest(http.MethodGet, "repos/o/r", nil)
+	c.Do(context.Background(), req, nil)
+	if _, ok := cache.Get(cacheKey(req)); ok {
+		t.Error("404 kept the cached response")
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	// the zero value works without NewMemoryCache
+	c := &MemoryCache{MaxEntries: 2}
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("empty cache returned a response")
+	}
+	c.Delete("a")
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+	c.Get("a")
+	c.Set("c", []byte("3"))
+	if _, ok := c.Get("b"); ok {
+		t.Error("the least recently used entry was kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+
+	c.Set("a", []byte("4"))
+	if b, _ := c.Get("a"); string(b) != "4" {
+		t.Errorf("a = %q after replacing it", b)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("a is still cached after Delete")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("a", []byte("response"))
+	if b, ok := c.Get("a"); !ok || string(b) != "response" {
+		t.Fatalf("Get(a) = %q, %v", b, ok)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("a is still cached after Delete")
+	}
+}
+
+func TestDiskCacheMaxAge(t *testing.T) {
+	c := &DiskCache{Dir: t.TempDir(), MaxAge: time.Hour}
+	c.Set("old", []byte("1"))
+	past := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(c.path("old"), past, past)
+
+	if _, ok := c.Get("old"); ok {
+		t.Error("a response past MaxAge was returned")
+	}
+	if _, err := os.Stat(c.path("old")); !os.IsNotExist(err) {
+		t.Errorf("the expired file was kept: %v", err)
+	}
+}
+
+func TestDiskCacheMaxBytes(t *testing.T) {
+	c := &DiskCache{Dir: t.TempDir(), MaxBytes: 20}
+	for i, key := range []string{"a", "b", "c"} {
+		c.Set(key, bytes.Repeat([]byte{'x'}, 10))
+		// one second apart so the modification times order the entries
+		at := time.Now().Add(time.Duration(i-3) * time.Second)
+		os.Chtimes(c.path(key), at, at)
+	}
+	// reading a marks it as recently used, so b is the oldest
+	c.Get("a")
+	c.pruned = time.Time{}
+	c.Set("d", bytes.Repeat([]byte{'x'}, 5))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": false, "d": true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%s) cached = %v, want %v", key, ok, want)
+		}
+	}
+}
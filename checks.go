@@ -0,0 +1,320 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// checkRunRequest is the body sent to create or update a check run, built from a CheckRun so
+// read-only fields such as IDs and URLs are never sent and an oversized output is truncated
+type checkRunRequest struct {
+	Name        string          `json:"name,omitempty"`
+	HeadSha     string          `json:"head_sha,omitempty"`
+	DetailsURL  string          `json:"details_url,omitempty"`
+	ExternalID  string          `json:"external_id,omitempty"`
+	Status      CheckStatus     `json:"status,omitempty"`
+	StartedAt   *Timestamp      `json:"started_at,omitempty"`
+	Conclusion  CheckConclusion `json:"conclusion,omitempty"`
+	CompletedAt *Timestamp      `json:"completed_at,omitempty"`
+	Output      *outputRequest  `json:"output,omitempty"`
+	Actions     []CheckAction   `json:"actions,omitempty"`
+}
+
+type outputRequest struct {
+	Title       string       `json:"title"`
+	Summary     string       `json:"summary"`
+	Text        string       `json:"text,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+func newCheckRunRequest(run CheckRun) checkRunRequest {
+	r := checkRunRequest{
+		Name:        run.Name,
+		HeadSha:     run.HeadSha,
+		DetailsURL:  run.DetailsURL,
+		ExternalID:  run.ExternalID,
+		Status:      run.Status,
+		Conclusion:  run.Conclusion,
+		CompletedAt: run.CompletedAt,
+		Actions:     run.Actions,
+	}
+	if !run.StartedAt.IsZero() {
+		r.StartedAt = &run.StartedAt
+	}
+	if run.Output.Title != "" || run.Output.Summary != "" || run.Output.Text != "" || len(run.Output.Annotations) > 0 {
+		r.Output = &outputRequest{
+			Title:       run.Output.Title,
+			Summary:     TruncateOutput(run.Output.Summary),
+			Text:        TruncateOutput(run.Output.Text),
+			Annotations: run.Output.Annotations,
+		}
+	}
+	return r
+}
+
+// CreateCheckRun creates a check run on owner/repo, Name and HeadSha of run are required
+func (c *Client) CreateCheckRun(ctx context.Context, owner, repo string, run CheckRun) (*CheckRun, *Response, error) {
+	if run.Name == "" || run.HeadSha == "" {
+		return nil, nil, fmt.Errorf("ghclient: check run name and head SHA are required")
+	}
+	return c.sendCheckRun(ctx, http.MethodPost, repoPath(owner, repo)+"/check-runs", newCheckRunRequest(run))
+}
+
+// UpdateCheckRun updates the check run with the fields set on run, the head SHA cannot be changed
+func (c *Client) UpdateCheckRun(ctx context.Context, owner, repo string, id int, run CheckRun) (*CheckRun, *Response, error) {
+	body := newCheckRunRequest(run)
+	body.HeadSha = ""
+	return c.sendCheckRun(ctx, http.MethodPatch, fmt.Sprintf("%s/check-runs/%d", repoPath(owner, repo), id), body)
+}
+
+// GetCheckRun returns a single check run
+func (c *Client) GetCheckRun(ctx context.Context, owner, repo string, id int) (*CheckRun, *Response, error) {
+	return c.sendCheckRun(ctx, http.MethodGet, fmt.Sprintf("%s/check-runs/%d", repoPath(owner, repo), id), nil)
+}
+
+func (c *Client) sendCheckRun(ctx context.Context, method, path string, body interface{}) (*CheckRun, *Response, error) {
+	req, err := c.NewRequest(method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	run := &CheckRun{}
+	resp, err := c.Do(ctx, req, run)
+	if err != nil {
+		return nil, resp, err
+	}
+	return run, resp, nil
+}
+
+// RerequestCheckRun asks GitHub to send a rerequested check_run event for the run
+func (c *Client) RerequestCheckRun(ctx context.Context, owner, repo string, id int) (*Response, error) {
+	req, err := c.NewRequest(http.MethodPost, fmt.Sprintf("%s/check-runs/%d/rerequest", repoPath(owner, repo), id), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// ListCheckRunsOptions filters the check runs returned by the list calls
+type ListCheckRunsOptions struct {
+	ListOptions
+	CheckName string
+	Status    CheckStatus
+	// Filter is "latest" (the default) or "all"
+	Filter string
+	AppID  int
+}
+
+func (o *ListCheckRunsOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if o.CheckName != "" {
+		v.Set("check_name", o.CheckName)
+	}
+	if o.Status != "" {
+		v.Set("status", string(o.Status))
+	}
+	if o.Filter != "" {
+		v.Set("filter", o.Filter)
+	}
+	if o.AppID != 0 {
+		v.Set("app_id", strconv.Itoa(o.AppID))
+	}
+	o.ListOptions.addValues(v)
+	return v
+}
+
+// CheckRunList is a page of check runs
+type CheckRunList struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+// ListCheckRunsForRef lists the check runs for a commit SHA, branch or tag
+func (c *Client) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckRunsOptions) (*CheckRunList, *Response, error) {
+	path := fmt.Sprintf("%s/commits/%s/check-runs", repoPath(owner, repo), escapeRef(ref))
+	return c.listCheckRuns(ctx, withQuery(path, opts.values()))
+}
+
+// ListCheckRunsInSuite lists the check runs of a check suite
+func (c *Client) ListCheckRunsInSuite(ctx context.Context, owner, repo string, suiteID int, opts *ListCheckRunsOptions) (*CheckRunList, *Response, error) {
+	path := fmt.Sprintf("%s/check-suites/%d/check-runs", repoPath(owner, repo), suiteID)
+	return c.listCheckRuns(ctx, withQuery(path, opts.values()))
+}
+
+// IterateCheckRunsForRef walks every check run for a commit SHA, branch or tag
+func (c *Client) IterateCheckRunsForRef(owner, repo, ref string, opts *ListCheckRunsOptions) *Iterator[CheckRun] {
+	return c.iterateCheckRuns(opts, func(ctx context.Context, opts *ListCheckRunsOptions) (*CheckRunList, *Response, error) {
+		return c.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	})
+}
+
+// IterateCheckRunsInSuite walks every check run of a check suite
+func (c *Client) IterateCheckRunsInSuite(owner, repo string, suiteID int, opts *ListCheckRunsOptions) *Iterator[CheckRun] {
+	return c.iterateCheckRuns(opts, func(ctx context.Context, opts *ListCheckRunsOptions) (*CheckRunList, *Response, error) {
+		return c.ListCheckRunsInSuite(ctx, owner, repo, suiteID, opts)
+	})
+}
+
+func (c *Client) iterateCheckRuns(opts *ListCheckRunsOptions, list func(context.Context, *ListCheckRunsOptions) (*CheckRunList, *Response, error)) *Iterator[CheckRun] {
+	o := ListCheckRunsOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return NewIterator(o.ListOptions, func(ctx context.Context, page ListOptions) ([]CheckRun, *Response, error) {
+		o.ListOptions = page
+		l, resp, err := list(ctx, &o)
+		if err != nil {
+			return nil, resp, err
+		}
+		return l.CheckRuns, resp, nil
+	})
+}
+
+func (c *Client) listCheckRuns(ctx context.Context, path string) (*CheckRunList, *Response, error) {
+	req, err := c.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := &CheckRunList{}
+	resp, err := c.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// CreateCheckSuite creates a check suite for the commit, only needed when automatic suite creation is disabled
+func (c *Client) CreateCheckSuite(ctx context.Context, owner, repo, headSha string) (*CheckSuite, *Response, error) {
+	req, err := c.NewRequest(http.MethodPost, repoPath(owner, repo)+"/check-suites", map[string]string{"head_sha": headSha})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	suite := &CheckSuite{}
+	resp, err := c.Do(ctx, req, suite)
+	if err != nil {
+		return nil, resp, err
+	}
+	return suite, resp, nil
+}
+
+// GetCheckSuite returns a single check suite
+func (c *Client) GetCheckSuite(ctx context.Context, owner, repo string, id int) (*CheckSuite, *Response, error) {
+	req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("%s/check-suites/%d", repoPath(owner, repo), id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	suite := &CheckSuite{}
+	resp, err := c.Do(ctx, req, suite)
+	if err != nil {
+		return nil, resp, err
+	}
+	return suite, resp, nil
+}
+
+// ListCheckSuitesOptions filters the check suites returned by ListCheckSuitesForRef
+type ListCheckSuitesOptions struct {
+	ListOptions
+	CheckName string
+	AppID     int
+}
+
+func (o *ListCheckSuitesOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if o.CheckName != "" {
+		v.Set("check_name", o.CheckName)
+	}
+	if o.AppID != 0 {
+		v.Set("app_id", strconv.Itoa(o.AppID))
+	}
+	o.ListOptions.addValues(v)
+	return v
+}
+
+// CheckSuiteList is a page of check suites
+type CheckSuiteList struct {
+	TotalCount  int          `json:"total_count"`
+	CheckSuites []CheckSuite `json:"check_suites"`
+}
+
+// ListCheckSuitesForRef lists the check suites for a commit SHA, branch or tag
+func (c *Client) ListCheckSuitesForRef(ctx context.Context, owner, repo, ref string, opts *ListCheckSuitesOptions) (*CheckSuiteList, *Response, error) {
+	path := fmt.Sprintf("%s/commits/%s/check-suites", repoPath(owner, repo), escapeRef(ref))
+	req, err := c.NewRequest(http.MethodGet, withQuery(path, opts.values()), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := &CheckSuiteList{}
+	resp, err := c.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// IterateCheckSuitesForRef walks every check suite for a commit SHA, branch or tag
+func (c *Client) IterateCheckSuitesForRef(owner, repo, ref string, opts *ListCheckSuitesOptions) *Iterator[CheckSuite] {
+	o := ListCheckSuitesOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return NewIterator(o.ListOptions, func(ctx context.Context, page ListOptions) ([]CheckSuite, *Response, error) {
+		o.ListOptions = page
+		l, resp, err := c.ListCheckSuitesForRef(ctx, owner, repo, ref, &o)
+		if err != nil {
+			return nil, resp, err
+		}
+		return l.CheckSuites, resp, nil
+	})
+}
+
+// RerequestCheckSuite asks GitHub to send a rerequested check_suite event for the suite
+func (c *Client) RerequestCheckSuite(ctx context.Context, owner, repo string, id int) (*Response, error) {
+	req, err := c.NewRequest(http.MethodPost, fmt.Sprintf("%s/check-suites/%d/rerequest", repoPath(owner, repo), id), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// AutoTriggerCheck enables or disables automatic check suite creation for an app on a repository
+type AutoTriggerCheck struct {
+	AppID   int  `json:"app_id"`
+	Setting bool `json:"setting"`
+}
+
+// CheckSuitePreferences are the check suite settings of a repository
+type CheckSuitePreferences struct {
+	Preferences struct {
+		AutoTriggerChecks []AutoTriggerCheck `json:"auto_trigger_checks"`
+	} `json:"preferences"`
+	Repository Repository `json:"repository"`
+}
+
+// SetCheckSuitePreferences changes whether check suites are created automatically for the given apps
+func (c *Client) SetCheckSuitePreferences(ctx context.Context, owner, repo string, checks []AutoTriggerCheck) (*CheckSuitePreferences, *Response, error) {
+	body := map[string][]AutoTriggerCheck{"auto_trigger_checks": checks}
+	req, err := c.NewRequest(http.MethodPatch, repoPath(owner, repo)+"/check-suites/preferences", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prefs := &CheckSuitePreferences{}
+	resp, err := c.Do(ctx, req, prefs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return prefs, resp, nil
+}
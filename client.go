@@ -0,0 +1,278 @@
+package ghclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultBaseURL is the REST API root of github.com
+	DefaultBaseURL = "https://api.github.com"
+	// DefaultAPIVersion is the REST API version requested unless Client.APIVersion is changed
+	DefaultAPIVersion = "2022-11-28"
+
+	defaultUserAgent = "ghclient"
+	mediaTypeJSON    = "application/vnd.github+json"
+)
+
+// Client talks to the GitHub REST API, authentication is handled by the transport of the http.Client it wraps
+type Client struct {
+	// BaseURL is the REST API root, it always ends with a slash
+	BaseURL *url.URL
+	// UserAgent is sent with every request, GitHub rejects requests without one
+	UserAgent string
+	// APIVersion is sent as X-GitHub-Api-Version to pin the REST API version
+	APIVersion string
+	// WaitOnRateLimit makes Do wait for a rate limit to pass and retry, as long as the wait ends before the
+	// context deadline. Otherwise a *RateLimitError or *AbuseRateLimitError is returned.
+	WaitOnRateLimit bool
+
+	client *http.Client
+
+	rateMu sync.Mutex
+	rates  map[string]Rate
+}
+
+// NewClient returns a client for github.com, httpClient defaults to http.DefaultClient
+func NewClient(httpClient *http.Client) *Client {
+	baseURL, _ := url.Parse(DefaultBaseURL + "/")
+	return newClient(baseURL, httpClient)
+}
+
+// NewEnterpriseClient returns a client for a GitHub Enterprise Server instance, /api/v3 is appended to the host URL when missing
+func NewEnterpriseClient(baseURL string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("ghclient: invalid enterprise URL: %s", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("ghclient: enterprise URL %q must be absolute", baseURL)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/api/v3") {
+		u.Path += "/api/v3"
+	}
+	u.Path += "/"
+	return newClient(u, httpClient), nil
+}
+
+func newClient(baseURL *url.URL, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		BaseURL:    baseURL,
+		UserAgent:  defaultUserAgent,
+		APIVersion: DefaultAPIVersion,
+		client:     httpClient,
+	}
+}
+
+// NewRequest builds a request for path, relative to BaseURL unless it is an absolute URL such as the *_url fields
+// of the models, body is encoded as JSON when it is not nil
+func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	rel, err := url.Parse(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("ghclient: invalid request path %q: %s", path, err)
+	}
+	u := c.BaseURL.ResolveReference(rel)
+
+	var buf io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("ghclient: cannot encode request body: %s", err)
+		}
+		buf = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, u.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", mediaTypeJSON)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.APIVersion != "" {
+		req.Header.Set("X-GitHub-Api-Version", c.APIVersion)
+	}
+	return req, nil
+}
+
+// Response wraps the http.Response returned by GitHub
+type Response struct {
+	*http.Response
+
+	// NextPage, PrevPage, FirstPage and LastPage are read from the Link header of list responses,
+	// zero when there is no such page
+	NextPage  int
+	PrevPage  int
+	FirstPage int
+	LastPage  int
+	// NextURL is the next page link, also set for endpoints paging with a cursor instead of page numbers
+	NextURL string
+
+	// Rate is the rate limit the request counted against
+	Rate Rate
+}
+
+func newResponse(r *http.Response) *Response {
+	response := &Response{Response: r, Rate: parseRate(r.Header)}
+	response.parseLinks()
+	return response
+}
+
+// Do sends the request and decodes the JSON response into v, or copies the body when v is an io.Writer.
+// Responses outside the 2xx range are returned as an *ErrorResponse, or as a *RateLimitError or
+// *AbuseRateLimitError when a rate limit rejected the request.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	for {
+		resp, err := c.do(ctx, req, v)
+		if !c.WaitOnRateLimit || !canReplay(req) || !waitForRateLimit(ctx, err) {
+			return resp, err
+		}
+		if req, err = replay(req); err != nil {
+			return resp, err
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if err := c.checkRateLimit(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		// the context error is more useful than the wrapped transport error
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response := newResponse(resp)
+	c.recordRate(response.Rate)
+	if err := CheckResponse(resp); err != nil {
+		return response, err
+	}
+
+	switch v := v.(type) {
+	case nil:
+	case io.Writer:
+		_, err = io.Copy(v, resp.Body)
+	default:
+		err = json.NewDecoder(resp.Body).Decode(v)
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	return response, err
+}
+
+// ErrorResponse is the error body GitHub returns for requests it rejects
+type ErrorResponse struct {
+	Response         *http.Response `json:"-"`
+	Message          string         `json:"message"`
+	Errors           []FieldError   `json:"errors"`
+	DocumentationURL string         `json:"documentation_url"`
+}
+
+func (e *ErrorResponse) Error() string {
+	if e.Response == nil || e.Response.Request == nil {
+		return "ghclient: " + e.Message
+	}
+	msg := fmt.Sprintf("ghclient: %s %s: %d %s", e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, e.Message)
+	for _, fe := range e.Errors {
+		msg += "; " + fe.Error()
+	}
+	return msg
+}
+
+// FieldError describes why GitHub rejected a single field of a request
+type FieldError struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// UnmarshalJSON also accepts the plain strings some endpoints return in place of error objects
+func (e *FieldError) UnmarshalJSON(b []byte) error {
+	var msg string
+	if json.Unmarshal(b, &msg) == nil {
+		*e = FieldError{Message: msg}
+		return nil
+	}
+
+	type fieldError FieldError
+	return json.Unmarshal(b, (*fieldError)(e))
+}
+
+func (e FieldError) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	msg := fmt.Sprintf("%s.%s is %s", e.Resource, e.Field, e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// CheckResponse returns an error when the response status is outside the 2xx range, a *RateLimitError or
+// *AbuseRateLimitError for rate limits and an *ErrorResponse otherwise
+func CheckResponse(r *http.Response) error {
+	if r.StatusCode >= 200 && r.StatusCode < 300 {
+		return nil
+	}
+
+	e := &ErrorResponse{Response: r}
+	b, _ := ioutil.ReadAll(r.Body)
+	if len(b) > 0 && json.Unmarshal(b, e) != nil {
+		e.Message = strings.TrimSpace(string(b))
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(r.StatusCode)
+	}
+	if err := rateLimitError(e); err != nil {
+		return err
+	}
+	return e
+}
+
+// repoPath returns the API path of a repository, escaping owner and name
+func repoPath(owner, repo string) string {
+	return "repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// withQuery appends the encoded query parameters to path
+func withQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+	return path + "?" + v.Encode()
+}
+
+// escapeRef escapes each segment of a Git ref, keeping the slashes of names like heads/main
+func escapeRef(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,129 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// setup returns a client sending requests through httpClient to a test server serving mux
+func setup(t *testing.T, httpClient *http.Client) (*Client, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(httpClient)
+	c.BaseURL = mustParseURL(t, srv.URL+"/")
+	return c, mux
+}
+
+func TestNewEnterpriseClient(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://ghe.example.com", "https://ghe.example.com/api/v3/"},
+		{"https://ghe.example.com/", "https://ghe.example.com/api/v3/"},
+		{"https://ghe.example.com/api/v3", "https://ghe.example.com/api/v3/"},
+		{"https://ghe.example.com/api/v3/", "https://ghe.example.com/api/v3/"},
+		{"https://example.com/github", "https://example.com/github/api/v3/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			c, err := NewEnterpriseClient(tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.BaseURL.String(); got != tt.want {
+				t.Errorf("BaseURL = %s, want %s", got, tt.want)
+			}
+			req, err := c.NewRequest(http.MethodGet, "/repos/o/r", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := req.URL.String(), tt.want+"repos/o/r"; got != want {
+				t.Errorf("request URL = %s, want %s", got, want)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"ghe.example.com", "/api/v3", "://"} {
+		if _, err := NewEnterpriseClient(invalid, nil); err == nil {
+			t.Errorf("NewEnterpriseClient(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestNewRequestHeaders(t *testing.T) {
+	c := NewClient(nil)
+	req, err := c.NewRequest(http.MethodPost, "repos/o/r/issues", map[string]string{"title": "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"Accept":               mediaTypeJSON,
+		"Content-Type":         "application/json",
+		"User-Agent":           defaultUserAgent,
+		"X-GitHub-Api-Version": DefaultAPIVersion,
+	} {
+		if got := req.Header.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	// absolute URLs such as the *_url fields are used as they are
+	req, err = c.NewRequest(http.MethodGet, "https://uploads.github.com/repos/o/r/releases/1/assets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := req.URL.Host; got != "uploads.github.com" {
+		t.Errorf("host = %s", got)
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"Validation Failed","errors":[{"resource":"Issue","field":"title","code":"missing_field"},"title is too long"],"documentation_url":"https://docs.github.com/rest/issues/issues#create-an-issue"}`)
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream timed out\n")
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req, _ := c.NewRequest(http.MethodPost, "repos/o/r/issues", struct{}{})
+	_, err := c.Do(context.Background(), req, nil)
+	var e *ErrorResponse
+	if !errors.As(err, &e) {
+		t.Fatalf("err = %#v, want *ErrorResponse", err)
+	}
+	if e.Message != "Validation Failed" || e.DocumentationURL == "" || len(e.Errors) != 2 {
+		t.Fatalf("decoded %+v", e)
+	}
+	if e.Errors[0] != (FieldError{Resource: "Issue", Field: "title", Code: "missing_field"}) {
+		t.Errorf("Errors[0] = %+v", e.Errors[0])
+	}
+	if e.Errors[1].Message != "title is too long" {
+		t.Errorf("Errors[1] = %+v", e.Errors[1])
+	}
+	if msg := e.Error(); !strings.Contains(msg, "422 Validation Failed") || !strings.Contains(msg, "Issue.title is missing_field") {
+		t.Errorf("Error() = %q", msg)
+	}
+
+	for path, want := range map[string]string{"plain": "upstream timed out", "empty": "Not Found"} {
+		req, _ := c.NewRequest(http.MethodGet, path, nil)
+		_, err := c.Do(context.Background(), req, nil)
+		if !errors.As(err, &e) || e.Message != want {
+			t.Errorf("GET /%s: err = %v, want message %q", path, err, want)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+// Command ghgen generates the ghclient webhook event types from the octokit/webhooks payload schemas.
+//
+// The schemas directory uses the octokit layout, one directory per event holding one
+// <action>.schema.json per action, or event.schema.json for events without actions,
+// and a common directory of shared definitions referenced with $ref. The examples
+// directory mirrors it with one <action>.payload.json per schema, the generated test
+// decodes each of them strictly into the generated event.
+//
+//	go run ./cmd/ghgen -schemas schemas/api.github.com -examples schemas/examples/api.github.com -out zz_generated.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// sharedTypes maps common schemas to the hand-maintained types used in their place
+var sharedTypes = map[string]string{
+	"common/installation-lite.schema.json": "Installation",
+	"common/organization.schema.json":      "Organization",
+	"common/repository.schema.json":        "Repository",
+	"common/user.schema.json":              "Account",
+}
+
+// typeNames renames generated types whose path-derived name reads badly, the names predate the generator
+var typeNames = map[string]string{
+	"CommitCommentComment": "CommitComment",
+	"Committer":            "GitActor",
+	"GollumPage":           "WikiPage",
+	"GollumPageAction":     "WikiPageAction",
+	"PageBuildBuild":       "PageBuild",
+	"PageBuildBuildError":  "PageBuildError",
+	"SimpleCommit":         "HeadCommit",
+	"StatusBranchCommit":   "BranchCommit",
+	"StatusCommitCommit":   "GitCommit",
+	"StatusCommitParent":   "CommitLink",
+}
+
+func main() {
+	schemas := flag.String("schemas", "schemas/api.github.com", "directory holding the octokit/webhooks payload schemas")
+	examples := flag.String("examples", "schemas/examples/api.github.com", "directory holding an example payload for every schema")
+	out := flag.String("out", "zz_generated.go", "file to write the generated code to, the test goes next to it")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("ghgen: ")
+
+	events, err := loadEvents(*schemas)
+	if err != nil {
+		log.Fatalf("Cannot load schemas: %s", err)
+	}
+
+	g := newGenerator()
+	for _, e := range events {
+		if err := g.event(e); err != nil {
+			log.Fatalf("Cannot generate %s: %s", e.name, err)
+		}
+	}
+	write(*out, g.bytes())
+
+	test, err := exampleTest(events, *examples, filepath.Dir(*out))
+	if err != nil {
+		log.Fatalf("Cannot generate test: %s", err)
+	}
+	write(strings.TrimSuffix(*out, ".go")+"_test.go", test)
+}
+
+func write(file string, code []byte) {
+	src, err := format.Source(code)
+	if err != nil {
+		log.Fatalf("Cannot format generated code for %s: %s", file, err)
+	}
+	if err := ioutil.WriteFile(file, src, 0644); err != nil {
+		log.Fatalf("Cannot write %s: %s", file, err)
+	}
+}
+
+// event is a webhook event and the schemas of each of its actions
+type event struct {
+	name    string
+	actions []string
+	schemas []*schema
+	// files are the schema files relative to the schemas root, in the order of schemas
+	files []string
+}
+
+// loadEvents reads every event directory below root, skipping the common definitions
+func loadEvents(root string) ([]event, error) {
+	dirs, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &loader{root: root, cache: map[string]*schema{}}
+	var events []event
+	for _, dir := range dirs {
+		if !dir.IsDir() || dir.Name() == "common" {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(root, dir.Name(), "*.schema.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+
+		e := event{name: dir.Name()}
+		for _, file := range files {
+			rel, _ := filepath.Rel(root, file)
+			s, err := l.load(filepath.ToSlash(rel))
+			if err != nil {
+				return nil, err
+			}
+			if action := strings.TrimSuffix(filepath.Base(file), ".schema.json"); action != "event" {
+				e.actions = append(e.actions, action)
+			}
+			e.schemas = append(e.schemas, s)
+			e.files = append(e.files, filepath.ToSlash(rel))
+		}
+		if len(e.schemas) > 0 {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+type generator struct {
+	buf   bytes.Buffer
+	types map[string]string
+	done  map[*schema]string
+	queue []pending
+}
+
+// pending is a named type discovered while generating a field, emitted after the current type
+type pending struct {
+	name string
+	s    *schema
+	// origin says where the type was found, it starts the doc comment when the schema has no fitting description
+	origin string
+}
+
+const header = "// Code generated by ghgen from the octokit/webhooks schemas. DO NOT EDIT.\n\npackage ghclient\n\n"
+
+func newGenerator() *generator {
+	g := &generator{types: map[string]string{}, done: map[*schema]string{}}
+	g.buf.WriteString(header)
+	return g
+}
+
+func (g *generator) bytes() []byte {
+	return g.buf.Bytes()
+}
+
+// event emits the event struct, merging the properties of every action, and its action enum
+func (g *generator) event(e event) error {
+	name := goName(e.name) + "Event"
+	merged := &schema{Type: schemaType{"object"}, Properties: map[string]*schema{}}
+	for _, s := range e.schemas {
+		for prop, p := range s.Properties {
+			if _, ok := merged.Properties[prop]; !ok {
+				merged.Properties[prop] = p
+			}
+		}
+		if len(e.actions) == 0 {
+			merged.Description = s.Description
+		}
+	}
+
+	if len(e.actions) > 0 {
+		action := goName(e.name) + "Action"
+		g.enum(action, fmt.Sprintf("%s is the action of a %s event", action, e.name), e.actions)
+		merged.Properties["action"] = &schema{goType: action}
+	}
+
+	fmt.Fprintf(&g.buf, "// %s is sent for the %s webhook.\n", name, e.name)
+	fmt.Fprintf(&g.buf, "type %s struct {\n\tPayload\n\n", name)
+	if err := g.fields(name, merged, true); err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.buf, "}\n\n")
+	return g.flush()
+}
+
+// flush emits the named types queued while generating fields
+func (g *generator) flush() error {
+	for len(g.queue) > 0 {
+		p := g.queue[0]
+		g.queue = g.queue[1:]
+		if p.s.isEnum() {
+			g.enum(p.name, typeDoc(p), p.s.enumValues())
+			continue
+		}
+		fmt.Fprintf(&g.buf, "// %s\ntype %s struct {\n", typeDoc(p), p.name)
+		if err := g.fields(p.name, p.s, false); err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.buf, "}\n\n")
+	}
+	return nil
+}
+
+func (g *generator) fields(parent string, s *schema, top bool) error {
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Slice(props, func(i, j int) bool {
+		// keep action first as the hand-written events do
+		if (props[i] == "action") != (props[j] == "action") {
+			return props[i] == "action"
+		}
+		return props[i] < props[j]
+	})
+
+	prefix := parent
+	if top {
+		prefix = strings.TrimSuffix(parent, "Event")
+	}
+	for _, prop := range props {
+		t, err := g.goType(prefix, fmt.Sprintf("the %s field of %s", prop, parent), prop, s.Properties[prop])
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", parent, prop, err)
+		}
+		fmt.Fprintf(&g.buf, "\t%s %s `json:\"%s\"`\n", goName(prop), t, prop)
+	}
+	return nil
+}
+
+// goType returns the Go type for a property, queueing any named types it needs
+func (g *generator) goType(parent, origin, prop string, s *schema) (string, error) {
+	if s.goType != "" {
+		return s.goType, nil
+	}
+
+	s, nullable := s.nonNull()
+	if s.Ref != "" {
+		if shared, ok := sharedTypes[s.Ref]; ok {
+			return pointer(shared, nullable), nil
+		}
+		if s.resolved == nil {
+			return "", fmt.Errorf("unresolved reference %s", s.Ref)
+		}
+		name := s.resolved.Title
+		if name == "" {
+			name = goName(strings.TrimSuffix(filepath.Base(s.Ref), ".schema.json"))
+		}
+		return pointer(g.named(name, s.resolved, "the shared "+s.Ref+" schema"), nullable), nil
+	}
+
+	switch s.Type.primary() {
+	case "string":
+		if s.Format == "date-time" {
+			return pointer("Timestamp", nullable), nil
+		}
+		if s.isEnum() {
+			return pointer(g.named(typeName(parent, prop), s, origin), nullable), nil
+		}
+		return pointer("string", nullable), nil
+	case "integer":
+		return pointer("int", nullable), nil
+	case "number":
+		return pointer("float64", nullable), nil
+	case "boolean":
+		return pointer("bool", nullable), nil
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}", nil
+		}
+		item, err := g.goType(parent, "an item of "+origin, singular(prop), s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}", nil
+		}
+		return pointer(g.named(typeName(parent, prop), s, origin), nullable), nil
+	}
+	return "interface{}", nil
+}
+
+// typeName names an inline type after the path to it, without repeating the parent name
+func typeName(parent, prop string) string {
+	field := goName(prop)
+	if strings.HasPrefix(field, parent) {
+		return field
+	}
+	return parent + field
+}
+
+// named returns the type name for s, queueing it for generation the first time it is seen
+func (g *generator) named(name string, s *schema, origin string) string {
+	if existing, ok := g.done[s]; ok {
+		return existing
+	}
+	if renamed, ok := typeNames[name]; ok {
+		name = renamed
+	}
+	base := name
+	for i := 2; g.types[name] != ""; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.types[name] = name
+	g.done[s] = name
+	g.queue = append(g.queue, pending{name: name, s: s, origin: origin})
+	return name
+}
+
+func (g *generator) enum(name, doc string, values []string) {
+	g.types[name] = name
+	fmt.Fprintf(&g.buf, "// %s\ntype %s string\n\n", doc, name)
+	fmt.Fprintf(&g.buf, "// Values of %s\nconst (\n", name)
+	for _, v := range values {
+		fmt.Fprintf(&g.buf, "\t%s%s %s = %q\n", name, goName(strings.ToLower(v)), name, v)
+	}
+	fmt.Fprintf(&g.buf, ")\n\n")
+
+	fmt.Fprintf(&g.buf, "// IsValid reports whether the value is one the schema defines\n")
+	fmt.Fprintf(&g.buf, "func (v %s) IsValid() bool {\n\tswitch v {\n\tcase ", name)
+	for i, v := range values {
+		if i > 0 {
+			g.buf.WriteString(", ")
+		}
+		g.buf.WriteString(name + goName(strings.ToLower(v)))
+	}
+	fmt.Fprintf(&g.buf, ":\n\t\treturn true\n\t}\n\treturn false\n}\n\n")
+}
+
+// typeDoc reads the schema description as the rest of "<name> is" when it starts with an article,
+// otherwise it says where the type was found and follows with the description
+func typeDoc(p pending) string {
+	desc := strings.TrimSpace(strings.SplitN(p.s.Description, "\n", 2)[0])
+	for _, article := range []string{"The ", "A ", "An "} {
+		if strings.HasPrefix(desc, article) {
+			return p.name + " is " + strings.ToLower(desc[:1]) + desc[1:]
+		}
+	}
+	doc := p.name + " is " + p.origin + "."
+	if desc != "" {
+		doc += " " + desc
+	}
+	return doc
+}
+
+func pointer(t string, nullable bool) string {
+	if nullable {
+		return "*" + t
+	}
+	return t
+}
+
+func singular(prop string) string {
+	switch {
+	case strings.HasSuffix(prop, "ches"):
+		return strings.TrimSuffix(prop, "es")
+	case strings.HasSuffix(prop, "s"):
+		return strings.TrimSuffix(prop, "s")
+	}
+	return prop
+}
+
+// initialisms follows the casing used by the hand-written types, so Sha stays Sha
+var initialisms = map[string]string{
+	"api":  "API",
+	"html": "HTML",
+	"id":   "ID",
+	"json": "JSON",
+	"ssh":  "SSH",
+	"uri":  "URI",
+	"url":  "URL",
+}
+
+// goName turns a snake_case schema name into an exported Go identifier
+func goName(s string) string {
+	switch s {
+	case "+1":
+		return "PlusOne"
+	case "-1":
+		return "MinusOne"
+	}
+
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' ' || r == '.' || r == '$'
+	}) {
+		if up, ok := initialisms[word]; ok {
+			b.WriteString(up)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// exampleTest generates a test decoding the example payload of every schema strictly into its event,
+// paths in the test are relative to dir, the directory of the generated code
+func exampleTest(events []event, examples, dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("import (\n\t\"os\"\n\t\"testing\"\n)\n\n")
+	buf.WriteString("func TestGeneratedEventExamples(t *testing.T) {\n")
+	buf.WriteString("\ttests := []struct {\n\t\texample string\n\t\tevent   func() interface{}\n\t}{\n")
+	for _, e := range events {
+		for _, file := range e.files {
+			example := filepath.Join(examples, filepath.FromSlash(strings.TrimSuffix(file, ".schema.json")+".payload.json"))
+			if _, err := os.Stat(example); err != nil {
+				return nil, fmt.Errorf("no example payload for %s: %s", file, err)
+			}
+			rel, err := filepath.Rel(dir, example)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&buf, "\t\t{%q, func() interface{} { return &%sEvent{} }},\n", filepath.ToSlash(rel), goName(e.name))
+		}
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString(`	for _, tt := range tests {
+		t.Run(tt.example, func(t *testing.T) {
+			payload, err := os.ReadFile(tt.example)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Decode(payload, tt.event(), DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+`)
+	return buf.Bytes(), nil
+}
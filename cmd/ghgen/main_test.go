@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"head_sha":            "HeadSha",
+		"html_url":            "HTMLURL",
+		"node_id":             "NodeID",
+		"ssh_url":             "SSHURL",
+		"commit_comment":      "CommitComment",
+		"checks_requested":    "ChecksRequested",
+		"merge-group":         "MergeGroup",
+		"merge_group$created": "MergeGroupCreated",
+		"page build":          "PageBuild",
+		"+1":                  "PlusOne",
+		"-1":                  "MinusOne",
+	}
+	for in, want := range tests {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"pages":    "page",
+		"branches": "branch",
+		"parents":  "parent",
+		"matches":  "match",
+		"commit":   "commit",
+	}
+	for in, want := range tests {
+		if got := singular(in); got != want {
+			t.Errorf("singular(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTypeDoc(t *testing.T) {
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{"The SHA of the merge group.", "Foo is the SHA of the merge group."},
+		{"A commit.", "Foo is a commit."},
+		{"An author.\nSecond line.", "Foo is an author."},
+		{"Explains why the merge group is being destroyed.", "Foo is the reason field of Bar. Explains why the merge group is being destroyed."},
+		{"Theme of the page", "Foo is the reason field of Bar. Theme of the page"},
+		{"", "Foo is the reason field of Bar."},
+	}
+	for _, tt := range tests {
+		p := pending{name: "Foo", s: &schema{Description: tt.desc}, origin: "the reason field of Bar"}
+		if got := typeDoc(p); got != tt.want {
+			t.Errorf("typeDoc(%q) = %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func writeSchemas(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLoaderResolve(t *testing.T) {
+	root := writeSchemas(t, map[string]string{
+		"push/event.schema.json": `{"properties": {
+			"head_commit": {"$ref": "common/commit.schema.json"},
+			"pusher": {"oneOf": [{"$ref": "../common/commit.schema.json"}, {"type": "null"}]},
+			"commits": {"items": {"$ref": "local.schema.json"}},
+			"sender": {"$ref": "common/user.schema.json"}
+		}}`,
+		"push/local.schema.json":       `{"title": "Local", "properties": {"id": {"type": "string"}}}`,
+		"common/commit.schema.json":    `{"title": "Commit", "properties": {"author": {"$ref": "committer.schema.json"}}}`,
+		"common/committer.schema.json": `{"title": "Committer", "properties": {"name": {"type": "string"}}}`,
+	})
+	l := &loader{root: root, cache: map[string]*schema{}}
+	s, err := l.load("push/event.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// refs resolve against the referring file first and the schemas root second
+	commit := s.Properties["head_commit"]
+	if commit.Ref != "common/commit.schema.json" || commit.resolved == nil || commit.resolved.Title != "Commit" {
+		t.Fatalf("head_commit = %+v", commit)
+	}
+	if pusher := s.Properties["pusher"].OneOf[0]; pusher.resolved != commit.resolved {
+		t.Error("the same file referenced twice was loaded twice")
+	}
+	if local := s.Properties["commits"].Items; local.Ref != "push/local.schema.json" || local.resolved == nil {
+		t.Errorf("commits item = %+v", local)
+	}
+	if author := commit.resolved.Properties["author"]; author.Ref != "common/committer.schema.json" || author.resolved == nil {
+		t.Errorf("nested ref = %+v", author)
+	}
+
+	// shared schemas map to hand-maintained types and are not loaded
+	if sender := s.Properties["sender"]; sender.resolved != nil || sharedTypes[sender.Ref] != "Account" {
+		t.Errorf("sender = %+v", sender)
+	}
+
+	if _, err := l.load("push/missing.schema.json"); err == nil {
+		t.Error("loading a missing schema succeeded")
+	}
+	root = writeSchemas(t, map[string]string{
+		"push/event.schema.json": `{"properties": {"head_commit": {"$ref": "common/missing.schema.json"}}}`,
+	})
+	l = &loader{root: root, cache: map[string]*schema{}}
+	if _, err := l.load("push/event.schema.json"); err == nil || !strings.Contains(err.Error(), "push/event.schema.json") {
+		t.Errorf("a dangling ref = %v, want an error naming the referring file", err)
+	}
+}
+
+// TestGeneratedUpToDate fails when zz_generated.go was edited by hand or the schemas changed without go generate
+func TestGeneratedUpToDate(t *testing.T) {
+	events, err := loadEvents("../../schemas/api.github.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGenerator()
+	for _, e := range events {
+		if err := g.event(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	test, err := exampleTest(events, "../../schemas/examples/api.github.com", "../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for file, code := range map[string][]byte{"zz_generated.go": g.bytes(), "zz_generated_test.go": test} {
+		want, err := format.Source(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadFile(filepath.Join("../..", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate", file)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+)
+
+// schema is the subset of JSON Schema draft 7 used by the octokit/webhooks schemas
+type schema struct {
+	ID          string             `json:"$id"`
+	Ref         string             `json:"$ref"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Type        schemaType         `json:"type"`
+	Format      string             `json:"format"`
+	Enum        []interface{}      `json:"enum"`
+	Properties  map[string]*schema `json:"properties"`
+	Required    []string           `json:"required"`
+	Items       *schema            `json:"items"`
+	OneOf       []*schema          `json:"oneOf"`
+	AnyOf       []*schema          `json:"anyOf"`
+
+	// resolved is the schema a $ref points at
+	resolved *schema
+	// goType overrides the generated Go type
+	goType string
+}
+
+// schemaType is either a single type name or a list of them
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = schemaType{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// primary returns the first type that is not null
+func (t schemaType) primary() string {
+	for _, name := range t {
+		if name != "null" {
+			return name
+		}
+	}
+	return ""
+}
+
+func (t schemaType) nullable() bool {
+	for _, name := range t {
+		if name == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *schema) isEnum() bool {
+	return s.Type.primary() == "string" && len(s.Enum) > 0
+}
+
+func (s *schema) enumValues() []string {
+	var values []string
+	for _, v := range s.Enum {
+		if str, ok := v.(string); ok {
+			values = append(values, str)
+		}
+	}
+	return values
+}
+
+// nonNull unwraps `oneOf: [X, {type: null}]` and `type: [X, null]`, reporting whether null is allowed
+func (s *schema) nonNull() (*schema, bool) {
+	alternatives := s.OneOf
+	if len(alternatives) == 0 {
+		alternatives = s.AnyOf
+	}
+	if len(alternatives) == 2 {
+		for i, alt := range alternatives {
+			if len(alt.Type) == 1 && alt.Type[0] == "null" {
+				return alternatives[1-i], true
+			}
+		}
+	}
+	return s, s.Type.nullable()
+}
+
+// loader reads schema files and resolves their references relative to the schemas root
+type loader struct {
+	root  string
+	cache map[string]*schema
+}
+
+func (l *loader) load(rel string) (*schema, error) {
+	if s, ok := l.cache[rel]; ok {
+		return s, nil
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(l.root, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, err
+	}
+	s := &schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%s: %s", rel, err)
+	}
+	l.cache[rel] = s
+
+	if err := l.resolve(s, path.Dir(rel)); err != nil {
+		return nil, fmt.Errorf("%s: %s", rel, err)
+	}
+	return s, nil
+}
+
+// resolve loads every $ref below s, refs are relative to the referring file or to the schemas root
+func (l *loader) resolve(s *schema, dir string) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if _, shared := sharedTypes[s.Ref]; shared {
+			return nil
+		}
+		ref := path.Join(dir, s.Ref)
+		if _, err := ioutil.ReadFile(filepath.Join(l.root, filepath.FromSlash(ref))); err != nil {
+			ref = s.Ref
+		}
+		if _, shared := sharedTypes[ref]; shared {
+			s.Ref = ref
+			return nil
+		}
+		resolved, err := l.load(ref)
+		if err != nil {
+			return err
+		}
+		s.Ref = ref
+		s.resolved = resolved
+		return nil
+	}
+
+	for _, p := range s.Properties {
+		if err := l.resolve(p, dir); err != nil {
+			return err
+		}
+	}
+	for _, alt := range append(s.OneOf, s.AnyOf...) {
+		if err := l.resolve(alt, dir); err != nil {
+			return err
+		}
+	}
+	return l.resolve(s.Items, dir)
+}
@@ -0,0 +1,221 @@
+package ghclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Payload retains the original JSON of a decoded event, it is embedded in every top-level event type
+type Payload struct {
+	// Raw is the payload exactly as GitHub sent it, suitable for forwarding downstream
+	Raw json.RawMessage `json:"-"`
+	// Unknown holds fields, and enum values such as actions, the model does not know about keyed by their dotted path,
+	// it is only populated with CollectUnknownFields
+	Unknown map[string]json.RawMessage `json:"-"`
+}
+
+func (p *Payload) payload() *Payload {
+	return p
+}
+
+// DecodeOption configures how Decode handles a payload
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	collect bool
+	strict  bool
+	actions bool
+	event   string
+}
+
+// CollectUnknownFields records fields the model does not know about in Payload.Unknown
+func CollectUnknownFields() DecodeOption {
+	return func(o *decodeOptions) {
+		o.collect = true
+	}
+}
+
+// DisallowUnknownFields makes Decode fail with an UnknownFieldsError when the payload has fields the model does not know about, meant for tests
+func DisallowUnknownFields() DecodeOption {
+	return func(o *decodeOptions) {
+		o.strict = true
+	}
+}
+
+// DisallowUnknownActions makes Decode fail with an UnknownActionError when the event action is not one the model defines.
+// Typed events such as PullRequestEvent are checked on their own, Event keeps the action as a plain string and is only
+// checked when the event is named with ForEvent.
+func DisallowUnknownActions() DecodeOption {
+	return func(o *decodeOptions) {
+		o.actions = true
+	}
+}
+
+// ForEvent names the event from the X-GitHub-Event header so DisallowUnknownActions can check the action of any type,
+// events without an action enum such as repository_dispatch are not checked
+func ForEvent(githubEvent string) DecodeOption {
+	return func(o *decodeOptions) {
+		o.event = githubEvent
+	}
+}
+
+// eventActions reports whether an action is one the model defines for the event
+var eventActions = map[string]func(string) bool{
+	CheckRunEventName:        func(a string) bool { return CheckRunAction(a).IsValid() },
+	CheckSuiteEventName:      func(a string) bool { return CheckSuiteAction(a).IsValid() },
+	CommitCommentEventName:   func(a string) bool { return CommitCommentAction(a).IsValid() },
+	InstallationEventName:    func(a string) bool { return InstallationAction(a).IsValid() },
+	MergeGroupEventName:      func(a string) bool { return MergeGroupAction(a).IsValid() },
+	PackageEventName:         func(a string) bool { return PackageAction(a).IsValid() },
+	PullRequestEventName:     func(a string) bool { return PullRequestAction(a).IsValid() },
+	RegistryPackageEventName: func(a string) bool { return PackageAction(a).IsValid() },
+}
+
+// UnknownActionError is returned when an event carries an action the model does not define
+type UnknownActionError struct {
+	Action string
+}
+
+func (e *UnknownActionError) Error() string {
+	return fmt.Sprintf("ghclient: unknown event action %q", e.Action)
+}
+
+// UnknownFieldsError lists the dotted paths of fields the model does not know about
+type UnknownFieldsError struct {
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "ghclient: payload contains unknown fields " + strings.Join(e.Fields, ", ")
+}
+
+// Decode unmarshals a webhook payload into v and keeps the raw payload when v embeds Payload
+func Decode(payload []byte, v interface{}, opts ...DecodeOption) error {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return err
+	}
+
+	p, hasPayload := v.(interface{ payload() *Payload })
+	if hasPayload {
+		p.payload().Raw = append(json.RawMessage(nil), payload...)
+		p.payload().Unknown = nil
+	}
+	if !o.collect && !o.strict && !o.actions {
+		return nil
+	}
+
+	unknown := map[string]json.RawMessage{}
+	collectUnknown(reflect.TypeOf(v), payload, "", unknown)
+	if action, ok := unknown["action"]; ok && o.actions {
+		var name string
+		json.Unmarshal(action, &name)
+		return &UnknownActionError{Action: name}
+	}
+	if valid, ok := eventActions[o.event]; ok && o.actions {
+		var e struct {
+			Action string `json:"action"`
+		}
+		if json.Unmarshal(payload, &e) == nil && e.Action != "" && !valid(e.Action) {
+			return &UnknownActionError{Action: e.Action}
+		}
+	}
+	if hasPayload && o.collect && len(unknown) > 0 {
+		p.payload().Unknown = unknown
+	}
+	if o.strict && len(unknown) > 0 {
+		fields := make([]string, 0, len(unknown))
+		for f := range unknown {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		return &UnknownFieldsError{Fields: fields}
+	}
+	return nil
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	enumType        = reflect.TypeOf((*interface{ IsValid() bool })(nil)).Elem()
+)
+
+// collectUnknown walks raw alongside t and records keys that no struct field decodes and enum values that are not valid
+func collectUnknown(t reflect.Type, raw json.RawMessage, path string, unknown map[string]json.RawMessage) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	raw = bytes.TrimSpace(raw)
+	if t.Kind() == reflect.String && t.Implements(enumType) {
+		v := reflect.New(t)
+		if json.Unmarshal(raw, v.Interface()) == nil && v.Elem().Len() > 0 && !v.Elem().Interface().(interface{ IsValid() bool }).IsValid() {
+			unknown[strings.TrimSuffix(path, ".")] = raw
+		}
+		return
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return
+		}
+		fields := map[string]reflect.Type{}
+		jsonFields(t, fields)
+		for key, value := range obj {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown[path+key] = value
+				continue
+			}
+			collectUnknown(field, value, path+key+".", unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return
+		}
+		for i, item := range items {
+			collectUnknown(t.Elem(), item, fmt.Sprintf("%s%d.", path, i), unknown)
+		}
+	}
+}
+
+// jsonFields records the lower-cased JSON names of the fields encoding/json decodes into t
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				jsonFields(ft, fields)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+}
@@ -0,0 +1,141 @@
+package ghclient
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const unknownFieldsPayload = `{
+	"pages": [
+		{"page_name": "Home", "action": "edited", "bogus": 1},
+		{"page_name": "About", "action": "renamed", "summary": null}
+	],
+	"repository": {"name": "Hello-World", "owner": {"login": "octocat", "twitter": "@octocat"}},
+	"sender": {"login": "octocat"},
+	"zen": "Keep it logically awesome."
+}`
+
+func TestDecodeKeepsRaw(t *testing.T) {
+	// whitespace and key order are kept so the payload can be forwarded or its signature checked again
+	var e GollumEvent
+	if err := Decode([]byte(unknownFieldsPayload), &e); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(e.Raw, []byte(unknownFieldsPayload)) {
+		t.Errorf("Raw = %s", e.Raw)
+	}
+	if e.Unknown != nil {
+		t.Errorf("Unknown = %v without CollectUnknownFields", e.Unknown)
+	}
+
+	// Raw is a copy, reusing the buffer does not change it
+	buf := []byte(`{"sender":{"login":"octocat"}}`)
+	if err := Decode(buf, &e); err != nil {
+		t.Fatal(err)
+	}
+	copy(buf, "XXXX")
+	if string(e.Raw) != `{"sender":{"login":"octocat"}}` {
+		t.Errorf("Raw = %s after the buffer changed", e.Raw)
+	}
+}
+
+func TestDecodeCollectUnknownFields(t *testing.T) {
+	var e GollumEvent
+	if err := Decode([]byte(unknownFieldsPayload), &e, CollectUnknownFields()); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"pages.0.bogus":            "1",
+		"pages.1.action":           `"renamed"`,
+		"repository.owner.twitter": `"@octocat"`,
+		"zen":                      `"Keep it logically awesome."`,
+	}
+	got := map[string]string{}
+	for path, raw := range e.Unknown {
+		got[path] = string(raw)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unknown = %v, want %v", got, want)
+	}
+	// the known fields still decode
+	if len(e.Pages) != 2 || e.Pages[1].PageName != "About" || e.Repository.Owner.Login != "octocat" {
+		t.Errorf("decoded %+v", e)
+	}
+
+	if err := Decode([]byte(`{"pages":[{"page_name":"Home","action":"edited"}]}`), &e, CollectUnknownFields()); err != nil {
+		t.Fatal(err)
+	}
+	if e.Unknown != nil {
+		t.Errorf("Unknown = %v for a payload without unknown fields", e.Unknown)
+	}
+}
+
+func TestDecodeDisallowUnknownFields(t *testing.T) {
+	var e GollumEvent
+	err := Decode([]byte(unknownFieldsPayload), &e, DisallowUnknownFields())
+	var ferr *UnknownFieldsError
+	if !errors.As(err, &ferr) {
+		t.Fatalf("err = %v, want an *UnknownFieldsError", err)
+	}
+	want := []string{"pages.0.bogus", "pages.1.action", "repository.owner.twitter", "zen"}
+	if !reflect.DeepEqual(ferr.Fields, want) {
+		t.Errorf("Fields = %v, want %v", ferr.Fields, want)
+	}
+	if msg := ferr.Error(); msg != "ghclient: payload contains unknown fields pages.0.bogus, pages.1.action, repository.owner.twitter, zen" {
+		t.Errorf("Error() = %q", msg)
+	}
+
+	// types without Payload are checked too
+	var r Repository
+	if err := Decode([]byte(`{"name":"Hello-World","stars":3}`), &r, DisallowUnknownFields()); !errors.As(err, &ferr) || !reflect.DeepEqual(ferr.Fields, []string{"stars"}) {
+		t.Errorf("err = %v", err)
+	}
+	if err := Decode([]byte(`{"name":`), &r, DisallowUnknownFields()); err == nil || errors.As(err, &ferr) {
+		t.Errorf("err = %v, want a syntax error", err)
+	}
+}
+
+func TestDecodeUnknownActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		v       interface{}
+		opts    []DecodeOption
+		want    string
+	}{
+		{"typed event", `{"action":"synchronise"}`, &PullRequestEvent{}, nil, "synchronise"},
+		{"nested enums are not actions", `{"action":"opened","pull_request":{"auto_merge":{"merge_method":"fast-forward"}}}`, &PullRequestEvent{}, nil, ""},
+		{"known action", `{"action":"synchronize"}`, &PullRequestEvent{}, nil, ""},
+		{"untyped event", `{"action":"synchronise"}`, &Event{}, nil, ""},
+		{"untyped event named", `{"action":"synchronise"}`, &Event{}, []DecodeOption{ForEvent(PullRequestEventName)}, "synchronise"},
+		{"untyped event named, known action", `{"action":"requested_action"}`, &Event{}, []DecodeOption{ForEvent(CheckRunEventName)}, ""},
+		{"untyped event, action of another event", `{"action":"requested_action"}`, &Event{}, []DecodeOption{ForEvent(CheckSuiteEventName)}, "requested_action"},
+		{"event without an action enum", `{"action":"deploy"}`, &Event{}, []DecodeOption{ForEvent(RepositoryDispatchEventName)}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Decode([]byte(tt.payload), tt.v, append(tt.opts, DisallowUnknownActions())...)
+			var aerr *UnknownActionError
+			if tt.want == "" {
+				if errors.As(err, &aerr) {
+					t.Fatalf("err = %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &aerr) {
+				t.Fatalf("err = %v, want an *UnknownActionError", err)
+			}
+			if aerr.Action != tt.want {
+				t.Errorf("Action = %q, want %q", aerr.Action, tt.want)
+			}
+		})
+	}
+
+	// without the option an unknown action decodes
+	var e PullRequestEvent
+	if err := Decode([]byte(`{"action":"synchronise"}`), &e); err != nil || e.Action != "synchronise" {
+		t.Errorf("Action = %q, err = %v", e.Action, err)
+	}
+}
@@ -0,0 +1,50 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// RepositoryDispatchEvent is triggered when a repository_dispatch is sent to a repository, the client payload is decoded into T.
+type RepositoryDispatchEvent[T any] struct {
+	Payload
+
+	Action        string       `json:"action"`
+	Branch        string       `json:"branch"`
+	ClientPayload T            `json:"client_payload"`
+	Repository    Repository   `json:"repository"`
+	Organization  Organization `json:"organization"`
+	Sender        Sender       `json:"sender"`
+	Installation  Installation `json:"installation"`
+}
+
+// RepositoryDispatch is the request body used to trigger a repository_dispatch event
+type RepositoryDispatch[T any] struct {
+	EventType     string `json:"event_type"`
+	ClientPayload T      `json:"client_payload"`
+}
+
+// ParseRepositoryDispatch decodes a repository_dispatch payload with a client payload of type T
+func ParseRepositoryDispatch[T any](payload []byte) (RepositoryDispatchEvent[T], error) {
+	var e RepositoryDispatchEvent[T]
+	if err := Decode(payload, &e); err != nil {
+		return e, fmt.Errorf("ghclient: cannot decode %s payload: %s", RepositoryDispatchEventName, err)
+	}
+	return e, nil
+}
+
+// DispatchRepository triggers a repository_dispatch event on owner/repo. It takes the client as an argument
+// because methods cannot have type parameters.
+func DispatchRepository[T any](ctx context.Context, c *Client, owner, repo string, dispatch RepositoryDispatch[T]) error {
+	if dispatch.EventType == "" {
+		return fmt.Errorf("ghclient: repository dispatch requires an event type")
+	}
+
+	req, err := c.NewRequest(http.MethodPost, repoPath(owner, repo)+"/dispatches", dispatch)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,73 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+type deployPayload struct {
+	Environment string `json:"environment"`
+	Ref         string `json:"ref"`
+	Unit        bool   `json:"unit"`
+}
+
+func TestParseRepositoryDispatch(t *testing.T) {
+	e, err := ParseRepositoryDispatch[deployPayload](readFixture(t, "repository_dispatch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := deployPayload{Environment: "staging", Ref: "ec26c3e57ca3a959ca5aad62de7213c562f8c821"}
+	if e.ClientPayload != want {
+		t.Errorf("ClientPayload = %+v, want %+v", e.ClientPayload, want)
+	}
+	if e.Action != "deploy" || e.Branch != "master" || e.Repository.FullName != "Octocoders/Hello-World" || e.Installation.ID != 2311213 {
+		t.Errorf("event = %q on %q of %q, installation %d", e.Action, e.Branch, e.Repository.FullName, e.Installation.ID)
+	}
+	if len(e.Raw) == 0 {
+		t.Error("Raw is empty")
+	}
+
+	if _, err := ParseRepositoryDispatch[deployPayload]([]byte(`{"action":"deploy","client_payload":{"unit":"yes"}}`)); err == nil {
+		t.Error("a client payload of the wrong type was accepted")
+	}
+}
+
+func TestDispatchRepository(t *testing.T) {
+	var requests int
+	var got struct {
+		EventType     string          `json:"event_type"`
+		ClientPayload json.RawMessage `json:"client_payload"`
+	}
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	dispatch := RepositoryDispatch[deployPayload]{
+		EventType:     "deploy",
+		ClientPayload: deployPayload{Environment: "staging", Ref: "main", Unit: true},
+	}
+	if err := DispatchRepository(context.Background(), c, "o", "r", dispatch); err != nil {
+		t.Fatal(err)
+	}
+	if got.EventType != "deploy" || string(got.ClientPayload) != `{"environment":"staging","ref":"main","unit":true}` {
+		t.Errorf("body = %s %s", got.EventType, got.ClientPayload)
+	}
+
+	err := DispatchRepository(context.Background(), c, "o", "r", RepositoryDispatch[deployPayload]{})
+	if err == nil || err.Error() != "ghclient: repository dispatch requires an event type" {
+		t.Errorf("err = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,337 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PackageType identifies the ecosystem of a package published to GitHub Packages
+type PackageType string
+
+// Package types sent by GitHub Packages
+const (
+	PackageTypeNpm       PackageType = "npm"
+	PackageTypeMaven     PackageType = "maven"
+	PackageTypeRubyGems  PackageType = "rubygems"
+	PackageTypeDocker    PackageType = "docker"
+	PackageTypeNuGet     PackageType = "nuget"
+	PackageTypeContainer PackageType = "container"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (t PackageType) IsValid() bool {
+	switch t {
+	case PackageTypeNpm, PackageTypeMaven, PackageTypeRubyGems, PackageTypeDocker, PackageTypeNuGet, PackageTypeContainer:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON normalizes the package type, GitHub sends container packages as "CONTAINER"
+func (t *PackageType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*t = PackageType(strings.ToLower(s))
+	return nil
+}
+
+// AccountType identifies the kind of account behind a user-like object
+type AccountType string
+
+// Account types sent by GitHub
+const (
+	AccountTypeUser         AccountType = "User"
+	AccountTypeBot          AccountType = "Bot"
+	AccountTypeOrganization AccountType = "Organization"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountTypeUser, AccountTypeBot, AccountTypeOrganization:
+		return true
+	}
+	return false
+}
+
+// CheckRunAction is the action of a check_run event
+type CheckRunAction string
+
+// Check run actions
+const (
+	CheckRunActionCreated         CheckRunAction = "created"
+	CheckRunActionCompleted       CheckRunAction = "completed"
+	CheckRunActionRerequested     CheckRunAction = "rerequested"
+	CheckRunActionRequestedAction CheckRunAction = "requested_action"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v CheckRunAction) IsValid() bool {
+	switch v {
+	case CheckRunActionCreated, CheckRunActionCompleted, CheckRunActionRerequested, CheckRunActionRequestedAction:
+		return true
+	}
+	return false
+}
+
+// CheckSuiteAction is the action of a check_suite event
+type CheckSuiteAction string
+
+// Check suite actions
+const (
+	CheckSuiteActionCompleted   CheckSuiteAction = "completed"
+	CheckSuiteActionRequested   CheckSuiteAction = "requested"
+	CheckSuiteActionRerequested CheckSuiteAction = "rerequested"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v CheckSuiteAction) IsValid() bool {
+	switch v {
+	case CheckSuiteActionCompleted, CheckSuiteActionRequested, CheckSuiteActionRerequested:
+		return true
+	}
+	return false
+}
+
+// InstallationAction is the action of an installation event
+type InstallationAction string
+
+// Installation actions
+const (
+	InstallationActionCreated                InstallationAction = "created"
+	InstallationActionDeleted                InstallationAction = "deleted"
+	InstallationActionNewPermissionsAccepted InstallationAction = "new_permissions_accepted"
+	InstallationActionSuspend                InstallationAction = "suspend"
+	InstallationActionUnsuspend              InstallationAction = "unsuspend"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v InstallationAction) IsValid() bool {
+	switch v {
+	case InstallationActionCreated, InstallationActionDeleted, InstallationActionNewPermissionsAccepted, InstallationActionSuspend, InstallationActionUnsuspend:
+		return true
+	}
+	return false
+}
+
+// PackageAction is the action of a package or registry_package event
+type PackageAction string
+
+// Package actions
+const (
+	PackageActionPublished PackageAction = "published"
+	PackageActionUpdated   PackageAction = "updated"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v PackageAction) IsValid() bool {
+	switch v {
+	case PackageActionPublished, PackageActionUpdated:
+		return true
+	}
+	return false
+}
+
+// PullRequestAction is the action of a pull_request event
+type PullRequestAction string
+
+// Pull request actions
+const (
+	PullRequestActionAssigned             PullRequestAction = "assigned"
+	PullRequestActionAutoMergeDisabled    PullRequestAction = "auto_merge_disabled"
+	PullRequestActionAutoMergeEnabled     PullRequestAction = "auto_merge_enabled"
+	PullRequestActionClosed               PullRequestAction = "closed"
+	PullRequestActionConvertedToDraft     PullRequestAction = "converted_to_draft"
+	PullRequestActionDemilestoned         PullRequestAction = "demilestoned"
+	PullRequestActionDequeued             PullRequestAction = "dequeued"
+	PullRequestActionEdited               PullRequestAction = "edited"
+	PullRequestActionEnqueued             PullRequestAction = "enqueued"
+	PullRequestActionLabeled              PullRequestAction = "labeled"
+	PullRequestActionLocked               PullRequestAction = "locked"
+	PullRequestActionMilestoned           PullRequestAction = "milestoned"
+	PullRequestActionOpened               PullRequestAction = "opened"
+	PullRequestActionReadyForReview       PullRequestAction = "ready_for_review"
+	PullRequestActionReopened             PullRequestAction = "reopened"
+	PullRequestActionReviewRequestRemoved PullRequestAction = "review_request_removed"
+	PullRequestActionReviewRequested      PullRequestAction = "review_requested"
+	PullRequestActionSynchronize          PullRequestAction = "synchronize"
+	PullRequestActionUnassigned           PullRequestAction = "unassigned"
+	PullRequestActionUnlabeled            PullRequestAction = "unlabeled"
+	PullRequestActionUnlocked             PullRequestAction = "unlocked"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v PullRequestAction) IsValid() bool {
+	switch v {
+	case PullRequestActionAssigned, PullRequestActionAutoMergeDisabled, PullRequestActionAutoMergeEnabled, PullRequestActionClosed, PullRequestActionConvertedToDraft, PullRequestActionDemilestoned, PullRequestActionDequeued, PullRequestActionEdited, PullRequestActionEnqueued, PullRequestActionLabeled, PullRequestActionLocked, PullRequestActionMilestoned, PullRequestActionOpened, PullRequestActionReadyForReview, PullRequestActionReopened, PullRequestActionReviewRequestRemoved, PullRequestActionReviewRequested, PullRequestActionSynchronize, PullRequestActionUnassigned, PullRequestActionUnlabeled, PullRequestActionUnlocked:
+		return true
+	}
+	return false
+}
+
+// CheckStatus is the status of a check run or check suite
+type CheckStatus string
+
+// Check statuses
+const (
+	CheckStatusQueued     CheckStatus = "queued"
+	CheckStatusInProgress CheckStatus = "in_progress"
+	CheckStatusCompleted  CheckStatus = "completed"
+	CheckStatusWaiting    CheckStatus = "waiting"
+	CheckStatusRequested  CheckStatus = "requested"
+	CheckStatusPending    CheckStatus = "pending"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v CheckStatus) IsValid() bool {
+	switch v {
+	case CheckStatusQueued, CheckStatusInProgress, CheckStatusCompleted, CheckStatusWaiting, CheckStatusRequested, CheckStatusPending:
+		return true
+	}
+	return false
+}
+
+// CheckConclusion is the final conclusion of a completed check run or check suite, empty until it completes
+type CheckConclusion string
+
+// Check conclusions
+const (
+	CheckConclusionActionRequired CheckConclusion = "action_required"
+	CheckConclusionCancelled      CheckConclusion = "cancelled"
+	CheckConclusionFailure        CheckConclusion = "failure"
+	CheckConclusionNeutral        CheckConclusion = "neutral"
+	CheckConclusionSuccess        CheckConclusion = "success"
+	CheckConclusionSkipped        CheckConclusion = "skipped"
+	CheckConclusionStale          CheckConclusion = "stale"
+	CheckConclusionTimedOut       CheckConclusion = "timed_out"
+	CheckConclusionStartupFailure CheckConclusion = "startup_failure"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v CheckConclusion) IsValid() bool {
+	switch v {
+	case CheckConclusionActionRequired, CheckConclusionCancelled, CheckConclusionFailure, CheckConclusionNeutral, CheckConclusionSuccess, CheckConclusionSkipped, CheckConclusionStale, CheckConclusionTimedOut, CheckConclusionStartupFailure:
+		return true
+	}
+	return false
+}
+
+// RepositorySelection tells whether an installation can access all or selected repositories
+type RepositorySelection string
+
+// Repository selections
+const (
+	RepositorySelectionAll      RepositorySelection = "all"
+	RepositorySelectionSelected RepositorySelection = "selected"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v RepositorySelection) IsValid() bool {
+	switch v {
+	case RepositorySelectionAll, RepositorySelectionSelected:
+		return true
+	}
+	return false
+}
+
+// AnnotationLevel is the severity of a check run annotation
+type AnnotationLevel string
+
+// Annotation levels, from least to most severe
+const (
+	AnnotationLevelNotice  AnnotationLevel = "notice"
+	AnnotationLevelWarning AnnotationLevel = "warning"
+	AnnotationLevelFailure AnnotationLevel = "failure"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v AnnotationLevel) IsValid() bool {
+	switch v {
+	case AnnotationLevelNotice, AnnotationLevelWarning, AnnotationLevelFailure:
+		return true
+	}
+	return false
+}
+
+// MergeMethod is how a pull request is merged into its base branch
+type MergeMethod string
+
+// Merge methods
+const (
+	MergeMethodMerge  MergeMethod = "merge"
+	MergeMethodSquash MergeMethod = "squash"
+	MergeMethodRebase MergeMethod = "rebase"
+)
+
+// IsValid reports whether the value is one GitHub accepts
+func (v MergeMethod) IsValid() bool {
+	switch v {
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+		return true
+	}
+	return false
+}
+
+// ReviewEvent is the verdict of a pull request review
+type ReviewEvent string
+
+// Review events, an empty event leaves the review pending
+const (
+	ReviewEventApprove        ReviewEvent = "APPROVE"
+	ReviewEventRequestChanges ReviewEvent = "REQUEST_CHANGES"
+	ReviewEventComment        ReviewEvent = "COMMENT"
+)
+
+// IsValid reports whether the value is one GitHub accepts
+func (v ReviewEvent) IsValid() bool {
+	switch v {
+	case ReviewEventApprove, ReviewEventRequestChanges, ReviewEventComment:
+		return true
+	}
+	return false
+}
+
+// FileStatus is how a pull request changed a file
+type FileStatus string
+
+// File statuses
+const (
+	FileStatusAdded     FileStatus = "added"
+	FileStatusRemoved   FileStatus = "removed"
+	FileStatusModified  FileStatus = "modified"
+	FileStatusRenamed   FileStatus = "renamed"
+	FileStatusCopied    FileStatus = "copied"
+	FileStatusChanged   FileStatus = "changed"
+	FileStatusUnchanged FileStatus = "unchanged"
+)
+
+// IsValid reports whether the value is one GitHub sends
+func (v FileStatus) IsValid() bool {
+	switch v {
+	case FileStatusAdded, FileStatusRemoved, FileStatusModified, FileStatusRenamed, FileStatusCopied, FileStatusChanged, FileStatusUnchanged:
+		return true
+	}
+	return false
+}
+
+// LockReason is why the conversation of an issue or pull request was locked
+type LockReason string
+
+// Lock reasons
+const (
+	LockReasonOffTopic  LockReason = "off-topic"
+	LockReasonTooHeated LockReason = "too heated"
+	LockReasonResolved  LockReason = "resolved"
+	LockReasonSpam      LockReason = "spam"
+)
+
+// IsValid reports whether the value is one GitHub accepts
+func (v LockReason) IsValid() bool {
+	switch v {
+	case LockReasonOffTopic, LockReasonTooHeated, LockReasonResolved, LockReasonSpam:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package ghclient
+
+//go:generate go run ./cmd/ghgen -schemas schemas/api.github.com -examples schemas/examples/api.github.com -out zz_generated.go
+
+// GitHub event names as sent in the X-GitHub-Event header
+const (
+	CheckRunEventName           = "check_run"
+	CheckSuiteEventName         = "check_suite"
+	CommitCommentEventName      = "commit_comment"
+	GollumEventName             = "gollum"
+	InstallationEventName       = "installation"
+	MergeGroupEventName         = "merge_group"
+	PackageEventName            = "package"
+	PageBuildEventName          = "page_build"
+	PullRequestEventName        = "pull_request"
+	RegistryPackageEventName    = "registry_package"
+	RepositoryDispatchEventName = "repository_dispatch"
+	StatusEventName             = "status"
+)
@@ -1 +1,3 @@
 module github.com/charlesgreen/ghclient
+
+go 1.18
@@ -0,0 +1,136 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// installationTokenRefresh is how long before expiry a cached installation token is replaced
+const installationTokenRefresh = 5 * time.Minute
+
+// InstallationToken is an access token that acts on behalf of an app installation
+type InstallationToken struct {
+	Token               string              `json:"token"`
+	ExpiresAt           Timestamp           `json:"expires_at"`
+	Permissions         Permissions         `json:"permissions"`
+	RepositorySelection RepositorySelection `json:"repository_selection"`
+	Repositories        []Repository        `json:"repositories"`
+}
+
+// TokenOptions scopes an installation token down to some repositories and permissions, empty fields keep everything the installation holds
+type TokenOptions struct {
+	Repositories  []string     `json:"repositories,omitempty"`
+	RepositoryIDs []int        `json:"repository_ids,omitempty"`
+	Permissions   *Permissions `json:"permissions,omitempty"`
+}
+
+// key identifies the scope of a token in the cache
+func (o *TokenOptions) key(installationID int) string {
+	if o == nil {
+		return fmt.Sprint(installationID)
+	}
+	repos := append([]string(nil), o.Repositories...)
+	sort.Strings(repos)
+	ids := append([]int(nil), o.RepositoryIDs...)
+	sort.Ints(ids)
+	perms, _ := json.Marshal(o.Permissions)
+	return fmt.Sprintf("%d|%s|%v|%s", installationID, strings.Join(repos, ","), ids, perms)
+}
+
+// InstallationTokenSource mints installation access tokens with the app JWT and caches them per installation
+// and scope until shortly before they expire. It is safe for concurrent use.
+type InstallationTokenSource struct {
+	// Client mints the tokens, its transport must authenticate as the app. Replace it with a client from
+	// NewEnterpriseClient wrapping an AppTransport to mint tokens on GitHub Enterprise Server.
+	Client *Client
+
+	mu     sync.Mutex
+	tokens map[string]*cachedToken
+
+	// now returns the current time used to check expiry
+	now func() time.Time
+}
+
+// cachedToken holds one scoped token, its lock makes concurrent callers wait for a single mint
+type cachedToken struct {
+	mu    sync.Mutex
+	token *InstallationToken
+}
+
+// NewInstallationTokenSource returns a token source minting tokens for the app on github.com, the requests are sent
+// with the transport and timeout of httpClient, which defaults to http.DefaultClient
+func NewInstallationTokenSource(app *AppTokenSource, httpClient *http.Client) *InstallationTokenSource {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	appClient := *httpClient
+	appClient.Transport = &AppTransport{Source: app, Base: httpClient.Transport}
+	return &InstallationTokenSource{
+		Client: NewClient(&appClient),
+		tokens: map[string]*cachedToken{},
+		now:    time.Now,
+	}
+}
+
+// Token returns a cached token for the installation and scope, minting a new one when it is missing or about to expire
+func (s *InstallationTokenSource) Token(ctx context.Context, installationID int, opts *TokenOptions) (*InstallationToken, error) {
+	entry := s.entry(opts.key(installationID))
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.token != nil && s.now().Add(installationTokenRefresh).Before(entry.token.ExpiresAt.Time) {
+		return entry.token, nil
+	}
+
+	token, err := s.mint(ctx, installationID, opts)
+	if err != nil {
+		return nil, err
+	}
+	entry.token = token
+	return token, nil
+}
+
+// Invalidate drops the cached token for the installation and scope, e.g. after GitHub rejected it
+func (s *InstallationTokenSource) Invalidate(installationID int, opts *TokenOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, opts.key(installationID))
+}
+
+func (s *InstallationTokenSource) entry(key string) *cachedToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.tokens[key]
+	if !ok {
+		entry = &cachedToken{}
+		s.tokens[key] = entry
+	}
+	return entry
+}
+
+// mint exchanges the app JWT for a new installation token, the AppTransport of the client retries with the next
+// key when GitHub rejects the JWT during key rotation
+func (s *InstallationTokenSource) mint(ctx context.Context, installationID int, opts *TokenOptions) (*InstallationToken, error) {
+	var body interface{} = struct{}{}
+	if opts != nil {
+		body = opts
+	}
+	req, err := s.Client.NewRequest(http.MethodPost, fmt.Sprintf("app/installations/%d/access_tokens", installationID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &InstallationToken{}
+	if _, err := s.Client.Do(ctx, req, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
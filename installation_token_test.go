@@ -0,0 +1,98 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInstallationTokenSourceMint(t *testing.T) {
+	var mints int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/app/installations/42/access_tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("User-Agent"); got != defaultUserAgent {
+			t.Errorf("User-Agent = %q", got)
+		}
+		if got := r.Header.Get("X-GitHub-Api-Version"); got != DefaultAPIVersion {
+			t.Errorf("X-GitHub-Api-Version = %q", got)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		n := atomic.AddInt32(&mints, 1)
+		fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`, n, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer srv.Close()
+
+	app, err := NewAppTokenSource(1, testKeys(t, 1)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := NewInstallationTokenSource(app, nil)
+	if tokens.Client, err = NewEnterpriseClient(srv.URL, (&AppTransport{Source: app}).Client()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := tokens.Token(context.Background(), 42, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Token != "ghs_1" {
+			t.Errorf("token = %q, want the cached ghs_1", token.Token)
+		}
+	}
+	if mints := atomic.LoadInt32(&mints); mints != 1 {
+		t.Errorf("minted %d tokens, want 1", mints)
+	}
+}
+
+func TestInstallationTokenSourceKeyFallback(t *testing.T) {
+	var rejected string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first JWT is signed with the retired key
+		if rejected == "" || rejected == r.Header.Get("Authorization") {
+			rejected = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Bad credentials"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"token":"ghs_new","expires_at":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer srv.Close()
+
+	app, err := NewAppTokenSource(1, testKeys(t, 2)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := NewInstallationTokenSource(app, nil)
+	tokens.Client.BaseURL = mustParseURL(t, srv.URL+"/")
+
+	token, err := tokens.Token(context.Background(), 1, &TokenOptions{Repositories: []string{"Hello-World"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != "ghs_new" {
+		t.Errorf("token = %q", token.Token)
+	}
+	if _, key, _ := app.Token(); key != 1 {
+		t.Errorf("signing with key %d, want 1", key)
+	}
+}
+
+func mustParseURL(t *testing.T, s string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
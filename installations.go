@@ -0,0 +1,44 @@
+package ghclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RepositoryList is a page of the repositories an installation can access
+type RepositoryList struct {
+	TotalCount          int                 `json:"total_count"`
+	Repositories        []Repository        `json:"repositories"`
+	RepositorySelection RepositorySelection `json:"repository_selection"`
+}
+
+// ListInstallationRepositories lists the repositories the installation authenticating the client can access
+func (c *Client) ListInstallationRepositories(ctx context.Context, opts *ListOptions) (*RepositoryList, *Response, error) {
+	v := url.Values{}
+	if opts != nil {
+		opts.addValues(v)
+	}
+	req, err := c.NewRequest(http.MethodGet, withQuery("installation/repositories", v), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list := &RepositoryList{}
+	resp, err := c.Do(ctx, req, list)
+	if err != nil {
+		return nil, resp, err
+	}
+	return list, resp, nil
+}
+
+// IterateInstallationRepositories walks every repository the installation authenticating the client can access
+func (c *Client) IterateInstallationRepositories(opts *ListOptions) *Iterator[Repository] {
+	return NewIterator(pageOf(opts), func(ctx context.Context, page ListOptions) ([]Repository, *Response, error) {
+		l, resp, err := c.ListInstallationRepositories(ctx, &page)
+		if err != nil {
+			return nil, resp, err
+		}
+		return l.Repositories, resp, nil
+	})
+}
@@ -0,0 +1,247 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func issuePath(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/issues/%d", repoPath(owner, repo), number)
+}
+
+// ParseIssueURL returns the repository and number of an issue from its API URL, such as the IssueURL of a
+// PullRequest or IssueComment. Pull requests are issues too, so their number works with every issue method.
+func ParseIssueURL(issueURL string) (owner, repo string, number int, err error) {
+	u, err := url.Parse(issueURL)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("ghclient: invalid issue URL %q: %s", issueURL, err)
+	}
+
+	// enterprise URLs have a /api/v3 prefix, so match from the end
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	n := len(segments)
+	if n < 5 || segments[n-5] != "repos" || (segments[n-2] != "issues" && segments[n-2] != "pulls") {
+		return "", "", 0, fmt.Errorf("ghclient: %q is not an issue URL", issueURL)
+	}
+	if number, err = strconv.Atoi(segments[n-1]); err != nil {
+		return "", "", 0, fmt.Errorf("ghclient: %q is not an issue URL", issueURL)
+	}
+	return segments[n-4], segments[n-3], number, nil
+}
+
+// CreateIssueComment adds a comment to the conversation of an issue or pull request
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) (*IssueComment, *Response, error) {
+	return c.CreateCommentAt(ctx, issuePath(owner, repo, number)+"/comments", body)
+}
+
+// CreateCommentAt adds a comment by posting to commentsURL, the CommentsURL of a PullRequest
+func (c *Client) CreateCommentAt(ctx context.Context, commentsURL, body string) (*IssueComment, *Response, error) {
+	return c.sendIssueComment(ctx, http.MethodPost, commentsURL, body)
+}
+
+// EditIssueComment replaces the body of a comment, apps can only edit their own comments
+func (c *Client) EditIssueComment(ctx context.Context, owner, repo string, id int, body string) (*IssueComment, *Response, error) {
+	return c.sendIssueComment(ctx, http.MethodPatch, fmt.Sprintf("%s/issues/comments/%d", repoPath(owner, repo), id), body)
+}
+
+func (c *Client) sendIssueComment(ctx context.Context, method, path, body string) (*IssueComment, *Response, error) {
+	req, err := c.NewRequest(method, path, map[string]string{"body": body})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comment := &IssueComment{}
+	resp, err := c.Do(ctx, req, comment)
+	if err != nil {
+		return nil, resp, err
+	}
+	return comment, resp, nil
+}
+
+// DeleteIssueComment deletes a comment, apps can only delete their own comments
+func (c *Client) DeleteIssueComment(ctx context.Context, owner, repo string, id int) (*Response, error) {
+	req, err := c.NewRequest(http.MethodDelete, fmt.Sprintf("%s/issues/comments/%d", repoPath(owner, repo), id), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// ListIssueCommentsOptions filters the comments returned by ListIssueComments
+type ListIssueCommentsOptions struct {
+	ListOptions
+	// Since only returns comments updated at or after this time
+	Since time.Time
+}
+
+func (o *ListIssueCommentsOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+	if !o.Since.IsZero() {
+		v.Set("since", o.Since.UTC().Format(time.RFC3339))
+	}
+	o.ListOptions.addValues(v)
+	return v
+}
+
+// ListIssueComments lists a page of the comments of an issue or pull request, oldest first
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *ListIssueCommentsOptions) ([]IssueComment, *Response, error) {
+	req, err := c.NewRequest(http.MethodGet, withQuery(issuePath(owner, repo, number)+"/comments", opts.values()), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var comments []IssueComment
+	resp, err := c.Do(ctx, req, &comments)
+	if err != nil {
+		return nil, resp, err
+	}
+	return comments, resp, nil
+}
+
+// IterateIssueComments walks every comment of an issue or pull request
+func (c *Client) IterateIssueComments(owner, repo string, number int, opts *ListIssueCommentsOptions) *Iterator[IssueComment] {
+	o := ListIssueCommentsOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return NewIterator(o.ListOptions, func(ctx context.Context, page ListOptions) ([]IssueComment, *Response, error) {
+		o.ListOptions = page
+		return c.ListIssueComments(ctx, owner, repo, number, &o)
+	})
+}
+
+// commentMarker hides the key identifying a comment posted by UpsertIssueComment in its Markdown
+func commentMarker(key string) string {
+	return "<!-- ghclient:" + key + " -->"
+}
+
+// BotLogin returns the login an app posts comments as when it acts as an installation, e.g. "my-app[bot]"
+func BotLogin(appSlug string) string {
+	return appSlug + "[bot]"
+}
+
+// FindIssueComment returns the first comment posted by UpsertIssueComment with key, nil when there is none. Only
+// comments by author count, a person quoting the comment would otherwise be mistaken for it. author is the login
+// of the credentials the client uses, BotLogin of the app slug for installation tokens.
+func (c *Client) FindIssueComment(ctx context.Context, owner, repo string, number int, author, key string) (*IssueComment, error) {
+	if author == "" {
+		return nil, fmt.Errorf("ghclient: the comment author is required")
+	}
+	marker := commentMarker(key)
+	it := c.IterateIssueComments(owner, repo, number, &ListIssueCommentsOptions{ListOptions: ListOptions{PerPage: MaxPerPage}})
+	for it.Next(ctx) {
+		if comment := it.Value(); strings.EqualFold(comment.User.Login, author) && strings.Contains(comment.Body, marker) {
+			return &comment, nil
+		}
+	}
+	return nil, it.Err()
+}
+
+// UpsertIssueComment keeps a single comment by author identified by key up to date, editing the comment it posted
+// before instead of adding a new one on every run
+func (c *Client) UpsertIssueComment(ctx context.Context, owner, repo string, number int, author, key, body string) (*IssueComment, *Response, error) {
+	existing, err := c.FindIssueComment(ctx, owner, repo, number, author, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body = commentMarker(key) + "\n" + body
+	if existing == nil {
+		return c.CreateIssueComment(ctx, owner, repo, number, body)
+	}
+	if existing.Body == body {
+		return existing, nil, nil
+	}
+	return c.EditIssueComment(ctx, owner, repo, existing.ID, body)
+}
+
+// AddLabels adds labels to an issue or pull request, creating labels the repository does not have yet, and returns
+// all labels now applied
+func (c *Client) AddLabels(ctx context.Context, owner, repo string, number int, labels ...string) ([]Label, *Response, error) {
+	return c.sendLabels(ctx, http.MethodPost, owner, repo, number, labels)
+}
+
+// SetLabels replaces the labels of an issue or pull request
+func (c *Client) SetLabels(ctx context.Context, owner, repo string, number int, labels ...string) ([]Label, *Response, error) {
+	if labels == nil {
+		labels = []string{}
+	}
+	return c.sendLabels(ctx, http.MethodPut, owner, repo, number, labels)
+}
+
+func (c *Client) sendLabels(ctx context.Context, method, owner, repo string, number int, labels []string) ([]Label, *Response, error) {
+	req, err := c.NewRequest(method, issuePath(owner, repo, number)+"/labels", map[string][]string{"labels": labels})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var applied []Label
+	resp, err := c.Do(ctx, req, &applied)
+	if err != nil {
+		return nil, resp, err
+	}
+	return applied, resp, nil
+}
+
+// RemoveLabel removes a label from an issue or pull request, GitHub answers 404 when it was not applied
+func (c *Client) RemoveLabel(ctx context.Context, owner, repo string, number int, label string) ([]Label, *Response, error) {
+	req, err := c.NewRequest(http.MethodDelete, issuePath(owner, repo, number)+"/labels/"+url.PathEscape(label), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var applied []Label
+	resp, err := c.Do(ctx, req, &applied)
+	if err != nil {
+		return nil, resp, err
+	}
+	return applied, resp, nil
+}
+
+// AddAssignees assigns users, by login, to an issue or pull request, users without access are ignored by GitHub
+func (c *Client) AddAssignees(ctx context.Context, owner, repo string, number int, assignees ...string) (*Response, error) {
+	return c.sendIssue(ctx, http.MethodPost, issuePath(owner, repo, number)+"/assignees", map[string][]string{"assignees": assignees})
+}
+
+// RemoveAssignees unassigns users, by login, from an issue or pull request
+func (c *Client) RemoveAssignees(ctx context.Context, owner, repo string, number int, assignees ...string) (*Response, error) {
+	return c.sendIssue(ctx, http.MethodDelete, issuePath(owner, repo, number)+"/assignees", map[string][]string{"assignees": assignees})
+}
+
+// SetMilestone tracks an issue or pull request against the milestone with the given number, nil clears it
+func (c *Client) SetMilestone(ctx context.Context, owner, repo string, number int, milestone *int) (*Response, error) {
+	return c.sendIssue(ctx, http.MethodPatch, issuePath(owner, repo, number), map[string]*int{"milestone": milestone})
+}
+
+func (c *Client) sendIssue(ctx context.Context, method, path string, body interface{}) (*Response, error) {
+	req, err := c.NewRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// LockIssue locks the conversation of an issue or pull request, reason may be empty
+func (c *Client) LockIssue(ctx context.Context, owner, repo string, number int, reason LockReason) (*Response, error) {
+	if reason != "" && !reason.IsValid() {
+		return nil, fmt.Errorf("ghclient: invalid lock reason %q", reason)
+	}
+	var body interface{}
+	if reason != "" {
+		body = map[string]LockReason{"lock_reason": reason}
+	}
+	return c.sendIssue(ctx, http.MethodPut, issuePath(owner, repo, number)+"/lock", body)
+}
+
+// UnlockIssue unlocks the conversation of an issue or pull request
+func (c *Client) UnlockIssue(ctx context.Context, owner, repo string, number int) (*Response, error) {
+	return c.sendIssue(ctx, http.MethodDelete, issuePath(owner, repo, number)+"/lock", nil)
+}
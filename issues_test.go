@@ -0,0 +1,62 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUpsertIssueComment(t *testing.T) {
+	marker := commentMarker("coverage")
+	comments := []IssueComment{
+		{ID: 1, User: User{Login: "alice", Type: AccountTypeUser}, Body: "> " + marker + "\n> old report"},
+		{ID: 2, User: User{Login: "other-app[bot]", Type: AccountTypeBot}, Body: marker + "\nreport"},
+		{ID: 3, User: User{Login: "my-app[bot]", Type: AccountTypeBot}, Body: marker + "\nold report"},
+	}
+
+	var method string
+	var edited int
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			method = r.Method
+			fmt.Fprint(w, `{"id":4}`)
+			return
+		}
+		json.NewEncoder(w).Encode(comments)
+	})
+	mux.HandleFunc("/repos/o/r/issues/comments/", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		fmt.Sscanf(r.URL.Path, "/repos/o/r/issues/comments/%d", &edited)
+		fmt.Fprintf(w, `{"id":%d}`, edited)
+	})
+
+	tests := []struct {
+		name       string
+		author     string
+		wantMethod string
+		wantID     int
+	}{
+		{"app edits its own comment", BotLogin("my-app"), http.MethodPatch, 3},
+		{"login is case insensitive", "My-App[bot]", http.MethodPatch, 3},
+		{"token user posts a new comment", "octocat", http.MethodPost, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method = ""
+			comment, _, err := c.UpsertIssueComment(context.Background(), "o", "r", 7, tt.author, "coverage", "new report")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if method != tt.wantMethod || comment.ID != tt.wantID {
+				t.Errorf("sent %s, got comment %d, want %s of %d", method, comment.ID, tt.wantMethod, tt.wantID)
+			}
+		})
+	}
+
+	if _, _, err := c.UpsertIssueComment(context.Background(), "o", "r", 7, "", "coverage", "new report"); err == nil {
+		t.Error("an empty author was accepted")
+	}
+}
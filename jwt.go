@@ -0,0 +1,191 @@
+package ghclient
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// jwtBackdate is subtracted from iat so the token is accepted when GitHub's clock is behind ours
+	jwtBackdate = 60 * time.Second
+	// jwtLifetime is the longest lifetime GitHub accepts for an app JWT
+	jwtLifetime = 10 * time.Minute
+	// jwtRefresh is how long before expiry a cached JWT is replaced
+	jwtRefresh = time.Minute
+)
+
+// ParsePrivateKey decodes a PEM encoded RSA private key in PKCS#1 or PKCS#8 form, as downloaded from the app settings
+func ParsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("ghclient: private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("ghclient: cannot parse private key: %s", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("ghclient: private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// PrivateKeyFromFile reads a PEM encoded private key from path
+func PrivateKeyFromFile(path string) (*rsa.PrivateKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ghclient: cannot read private key: %s", err)
+	}
+	return ParsePrivateKey(b)
+}
+
+// PrivateKeyFromEnv reads a private key from the environment variable, which holds either the PEM itself
+// (with real or escaped newlines) or the PEM encoded as base64
+func PrivateKeyFromEnv(name string) (*rsa.PrivateKey, error) {
+	v := strings.TrimSpace(os.Getenv(name))
+	if v == "" {
+		return nil, fmt.Errorf("ghclient: environment variable %s is not set", name)
+	}
+
+	if !strings.Contains(v, "-----BEGIN") {
+		decoded, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("ghclient: %s is neither PEM nor base64: %s", name, err)
+		}
+		v = string(decoded)
+	}
+	return ParsePrivateKey([]byte(strings.Replace(v, `\n`, "\n", -1)))
+}
+
+// AppTokenSource signs the JWTs used to authenticate as a GitHub App, it caches the current JWT and
+// signs a new one shortly before it expires. It is safe for concurrent use.
+type AppTokenSource struct {
+	appID int
+
+	mu      sync.Mutex
+	keys    []*rsa.PrivateKey
+	key     int
+	token   string
+	expires time.Time
+
+	// now returns the current time used for iat and exp
+	now func() time.Time
+}
+
+// NewAppTokenSource returns a token source for the app, keys are tried in order so the key being
+// rotated in can be listed after the current one
+func NewAppTokenSource(appID int, keys ...*rsa.PrivateKey) (*AppTokenSource, error) {
+	if appID <= 0 {
+		return nil, fmt.Errorf("ghclient: app ID is required")
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("ghclient: at least one private key is required")
+	}
+	return &AppTokenSource{appID: appID, keys: keys, now: time.Now}, nil
+}
+
+// AppID returns the ID of the app the tokens are issued for
+func (s *AppTokenSource) AppID() int {
+	return s.appID
+}
+
+// Token returns a valid app JWT and the index of the key that signed it, signing a new one when the
+// cached token is about to expire. Pass the index to Fallback when GitHub rejects the token.
+func (s *AppTokenSource) Token() (string, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Add(jwtRefresh).Before(s.expires) {
+		return s.token, s.key, nil
+	}
+
+	iat := now.Add(-jwtBackdate)
+	exp := iat.Add(jwtLifetime)
+	token, err := signJWT(s.keys[s.key], jwtClaims{
+		IssuedAt:  iat.Unix(),
+		ExpiresAt: exp.Unix(),
+		Issuer:    s.appID,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	s.token, s.expires = token, exp
+	return token, s.key, nil
+}
+
+// Fallback switches to the next private key after GitHub rejected a JWT signed with the key at failed. Concurrent
+// requests rejected with the same key advance it only once, when another request already moved past failed the
+// current key is kept. It returns false when the last key was rejected, the source then starts over at the first
+// key so a single rejection, e.g. from clock skew, cannot leave it signing with a key GitHub does not know.
+func (s *AppTokenSource) Fallback(failed int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key != failed {
+		return true
+	}
+	s.token = ""
+	if s.key+1 >= len(s.keys) {
+		s.key = 0
+		return false
+	}
+	s.key++
+	return true
+}
+
+// SetKeys replaces the private keys, used to rotate keys without restarting
+func (s *AppTokenSource) SetKeys(keys ...*rsa.PrivateKey) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("ghclient: at least one private key is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys, s.key, s.token = keys, 0, ""
+	return nil
+}
+
+type jwtClaims struct {
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+	Issuer    int   `json:"iss"`
+}
+
+// signJWT encodes the claims as an RS256 signed JWT
+func signJWT(key *rsa.PrivateKey, claims jwtClaims) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("ghclient: cannot sign app JWT: %s", err)
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,288 @@
+package ghclient
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testKeys(t *testing.T, n int) []*rsa.PrivateKey {
+	t.Helper()
+	keys := make([]*rsa.PrivateKey, n)
+	for i := range keys {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// verifyJWT checks the RS256 signature of token with key and returns its claims
+func verifyJWT(t *testing.T, token string, key *rsa.PrivateKey) jwtClaims {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts", len(parts))
+	}
+	enc := base64.RawURLEncoding
+
+	var header map[string]string
+	if b, err := enc.DecodeString(parts[0]); err != nil || json.Unmarshal(b, &header) != nil {
+		t.Fatalf("cannot decode header %q", parts[0])
+	}
+	if header["alg"] != "RS256" || header["typ"] != "JWT" {
+		t.Errorf("header = %v", header)
+	}
+
+	sig, err := enc.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key.Public().(*rsa.PublicKey), crypto.SHA256, digest[:], sig); err != nil {
+		t.Fatalf("signature: %s", err)
+	}
+
+	var claims jwtClaims
+	if b, err := enc.DecodeString(parts[1]); err != nil || json.Unmarshal(b, &claims) != nil {
+		t.Fatalf("cannot decode claims %q", parts[1])
+	}
+	return claims
+}
+
+func TestAppTokenSourceToken(t *testing.T) {
+	keys := testKeys(t, 1)
+	s, err := NewAppTokenSource(42, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	s.now = func() time.Time { return now }
+
+	token, key, err := s.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != 0 {
+		t.Errorf("signed with key %d", key)
+	}
+	claims := verifyJWT(t, token, keys[0])
+	if want := now.Add(-60 * time.Second).Unix(); claims.IssuedAt != want {
+		t.Errorf("iat = %d, want %d", claims.IssuedAt, want)
+	}
+	if want := claims.IssuedAt + int64((10 * time.Minute).Seconds()); claims.ExpiresAt != want {
+		t.Errorf("exp = %d, want %d", claims.ExpiresAt, want)
+	}
+	if claims.Issuer != 42 {
+		t.Errorf("iss = %d", claims.Issuer)
+	}
+
+	// the token is reused until jwtRefresh before it expires
+	expires := time.Unix(claims.ExpiresAt, 0)
+	now = expires.Add(-jwtRefresh - time.Second)
+	if cached, _, _ := s.Token(); cached != token {
+		t.Error("token was signed again before the refresh window")
+	}
+	now = expires.Add(-jwtRefresh)
+	refreshed, _, err := s.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed == token {
+		t.Fatal("token was not refreshed")
+	}
+	if claims := verifyJWT(t, refreshed, keys[0]); claims.IssuedAt != now.Add(-jwtBackdate).Unix() {
+		t.Errorf("refreshed iat = %d", claims.IssuedAt)
+	}
+}
+
+func TestAppTokenSourceFallback(t *testing.T) {
+	s, err := NewAppTokenSource(1, testKeys(t, 3)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, key, err := s.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every request rejected with key 0 falls back, but only the first one advances
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !s.Fallback(key) {
+				t.Error("Fallback(0) = false")
+			}
+		}()
+	}
+	wg.Wait()
+	if _, key, _ = s.Token(); key != 1 {
+		t.Fatalf("signed with key %d after concurrent fallbacks, want 1", key)
+	}
+
+	if !s.Fallback(1) {
+		t.Fatal("Fallback(1) = false")
+	}
+	// the last key being rejected too starts over at the first key
+	if s.Fallback(2) {
+		t.Error("Fallback on the last key = true")
+	}
+	if _, key, _ = s.Token(); key != 0 {
+		t.Errorf("signed with key %d after the last key was rejected, want 0", key)
+	}
+}
+
+func TestAppTransportRecoversFromTransientRejection(t *testing.T) {
+	keys := testKeys(t, 2)
+	var mu sync.Mutex
+	var requests, rejected int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(token, ".")
+		sig, _ := base64.RawURLEncoding.DecodeString(parts[len(parts)-1])
+		digest := sha256.Sum256([]byte(strings.Join(parts[:len(parts)-1], ".")))
+		// only the first key is registered, and the very first request is rejected e.g. for clock skew
+		if requests == 1 || rsa.VerifyPKCS1v15(&keys[0].PublicKey, crypto.SHA256, digest[:], sig) != nil {
+			rejected++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}))
+	defer srv.Close()
+
+	s, err := NewAppTokenSource(1, keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := (&AppTransport{Source: s}).Client()
+
+	var statuses []int
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+	// the first request fails with both keys, every later one is signed with the first key again
+	if want := []int{401, 200, 200, 200}; !equalInts(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+	if rejected != 2 {
+		t.Errorf("%d requests rejected, want 2", rejected)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := testKeys(t, 1)[0]
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		wantErr bool
+	}{
+		{"pkcs1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), false},
+		{"pkcs8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), false},
+		{"ecdsa", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8}), true},
+		{"not pem", []byte("-----BEGIN nothing"), true},
+		{"garbage", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParsePrivateKey(tt.pem)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsed.Equal(key) {
+				t.Error("parsed a different key")
+			}
+		})
+	}
+}
+
+func TestPrivateKeyFromEnv(t *testing.T) {
+	key := testKeys(t, 1)[0]
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"pem", pemKey},
+		{"escaped newlines", strings.Replace(pemKey, "\n", `\n`, -1)},
+		{"base64", base64.StdEncoding.EncodeToString([]byte(pemKey))},
+		{"surrounding space", "\n  " + pemKey + "  \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GHCLIENT_TEST_KEY", tt.value)
+			parsed, err := PrivateKeyFromEnv("GHCLIENT_TEST_KEY")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsed.Equal(key) {
+				t.Error("parsed a different key")
+			}
+		})
+	}
+
+	t.Setenv("GHCLIENT_TEST_KEY", "")
+	if _, err := PrivateKeyFromEnv("GHCLIENT_TEST_KEY"); err == nil {
+		t.Error("an empty variable was accepted")
+	}
+	t.Setenv("GHCLIENT_TEST_KEY", "not base64!")
+	if _, err := PrivateKeyFromEnv("GHCLIENT_TEST_KEY"); err == nil {
+		t.Error("a value that is neither PEM nor base64 was accepted")
+	}
+}
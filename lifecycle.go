@@ -0,0 +1,231 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// completeTimeout bounds the final update of a check run, which runs after the handler's context may have expired
+const completeTimeout = 15 * time.Second
+
+// CheckFunc performs a check on the run's head commit and returns its conclusion and output, returning an error
+// concludes the run as a failure with the error in the summary
+type CheckFunc func(ctx context.Context, run *ActiveCheckRun) (CheckConclusion, Output, error)
+
+// CheckRunner drives a check run through queued, in_progress and completed, and guarantees the run is completed even
+// when the check fails, panics or runs out of time
+type CheckRunner struct {
+	Client *Client
+	// Name is the check run name shown on GitHub
+	Name string
+	// DetailsURL links the check run to the full results
+	DetailsURL string
+}
+
+// ActiveCheckRun is a check run being worked on by a CheckFunc
+type ActiveCheckRun struct {
+	CheckRun
+	Owner string
+	Repo  string
+
+	client *Client
+}
+
+// Update reports progress on the running check
+func (r *ActiveCheckRun) Update(ctx context.Context, output Output) error {
+	_, _, err := r.client.UpdateCheckRun(ctx, r.Owner, r.Repo, r.ID, CheckRun{Output: output})
+	return err
+}
+
+// Annotate attaches annotations to the running check, see UpdateCheckRunAnnotations
+func (r *ActiveCheckRun) Annotate(ctx context.Context, output Output, annotations []Annotation) (*AnnotationReport, error) {
+	return r.client.UpdateCheckRunAnnotations(ctx, r.Owner, r.Repo, r.ID, output, annotations, nil)
+}
+
+// Client returns the client the run is updated with
+func (r *ActiveCheckRun) Client() *Client {
+	return r.client
+}
+
+// ExternalID returns the ID correlating check runs of this runner with a commit
+func (cr *CheckRunner) ExternalID(headSha string) string {
+	return cr.Name + ":" + headSha
+}
+
+// HandleCheckSuite runs the check when a check suite is requested or rerequested, other actions are ignored
+func (cr *CheckRunner) HandleCheckSuite(ctx context.Context, e CheckSuiteEvent, fn CheckFunc) error {
+	if e.Action != CheckSuiteActionRequested && e.Action != CheckSuiteActionRerequested {
+		return nil
+	}
+	return cr.Run(ctx, e.Repository.Owner.Login, e.Repository.Name, e.CheckSuite.HeadSha, fn)
+}
+
+// HandleCheckRun runs the check again when one of this runner's check runs is rerequested, other actions are ignored
+func (cr *CheckRunner) HandleCheckRun(ctx context.Context, e CheckRunEvent, fn CheckFunc) error {
+	if e.Action != CheckRunActionRerequested || e.CheckRun.Name != cr.Name {
+		return nil
+	}
+	return cr.Run(ctx, e.Repository.Owner.Login, e.Repository.Name, e.CheckRun.HeadSha, fn)
+}
+
+// HandleMergeGroup runs the check when a merge queue entry requests checks, other actions are ignored
+func (cr *CheckRunner) HandleMergeGroup(ctx context.Context, e MergeGroupEvent, fn CheckFunc) error {
+	if e.Action != MergeGroupActionChecksRequested {
+		return nil
+	}
+	return cr.Run(ctx, e.Repository.Owner.Login, e.Repository.Name, e.MergeGroup.HeadSha, fn)
+}
+
+// Run creates a queued check run for the commit, or picks up the unfinished one from an earlier delivery, moves it to
+// in_progress, calls fn and completes the run with its result. When ctx is done before fn returns the run is completed
+// right away as timed_out or cancelled, and a panic in fn completes it as cancelled before the panic continues.
+func (cr *CheckRunner) Run(ctx context.Context, owner, repo, headSha string, fn CheckFunc) error {
+	run, err := cr.start(ctx, owner, repo, headSha)
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		conclusion CheckConclusion
+		output     Output
+		err        error
+		panic      interface{}
+	}
+	// buffered so fn can finish after Run has given up on it
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		defer func() {
+			r.panic = recover()
+			done <- r
+		}()
+		r.conclusion, r.output, r.err = fn(ctx, run)
+	}()
+
+	// finished is false when ctx was done first, a result that lands as the deadline passes is still used
+	var r result
+	var finished bool
+	select {
+	case r = <-done:
+		finished = true
+	case <-ctx.Done():
+		select {
+		case r = <-done:
+			finished = true
+		default:
+		}
+	}
+
+	conclusion, output := r.conclusion, r.output
+	switch {
+	case r.panic != nil:
+		conclusion, output = CheckConclusionCancelled, withSummary(output, cr.Name, fmt.Sprintf("The check panicked: %v", r.panic))
+	case !finished && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		conclusion, output = CheckConclusionTimedOut, withSummary(output, cr.Name, "The check ran out of time.")
+	case !finished:
+		conclusion, output = CheckConclusionCancelled, withSummary(output, cr.Name, "The check was cancelled.")
+	case r.err != nil:
+		conclusion, output = CheckConclusionFailure, withSummary(output, cr.Name, "The check failed: "+r.err.Error())
+	case !conclusion.IsValid():
+		conclusion = CheckConclusionNeutral
+	}
+
+	completeErr := cr.complete(run, conclusion, output)
+	if r.panic != nil {
+		panic(r.panic)
+	}
+	if !finished {
+		r.err = ctx.Err()
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return completeErr
+}
+
+// start finds or creates the check run and moves it to in_progress
+func (cr *CheckRunner) start(ctx context.Context, owner, repo, headSha string) (*ActiveCheckRun, error) {
+	run, err := cr.find(ctx, owner, repo, headSha)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		run, _, err = cr.Client.CreateCheckRun(ctx, owner, repo, CheckRun{
+			Name:       cr.Name,
+			HeadSha:    headSha,
+			ExternalID: cr.ExternalID(headSha),
+			DetailsURL: cr.DetailsURL,
+			Status:     CheckStatusQueued,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updated, _, err := cr.Client.UpdateCheckRun(ctx, owner, repo, run.ID, CheckRun{
+		Status:    CheckStatusInProgress,
+		StartedAt: Timestamp{time.Now()},
+	})
+	if err != nil {
+		// the run was created, make sure it does not stay queued forever
+		cr.complete(&ActiveCheckRun{CheckRun: *run, Owner: owner, Repo: repo, client: cr.Client}, CheckConclusionCancelled,
+			Output{Title: cr.Name, Summary: "The check could not be started."})
+		return nil, err
+	}
+	return &ActiveCheckRun{CheckRun: *updated, Owner: owner, Repo: repo, client: cr.Client}, nil
+}
+
+// find returns the unfinished check run with this runner's external ID for the commit, if any
+func (cr *CheckRunner) find(ctx context.Context, owner, repo, headSha string) (*CheckRun, error) {
+	externalID := cr.ExternalID(headSha)
+	opts := &ListCheckRunsOptions{CheckName: cr.Name, ListOptions: ListOptions{PerPage: MaxPerPage}}
+	it := cr.Client.IterateCheckRunsForRef(owner, repo, headSha, opts)
+	for it.Next(ctx) {
+		if run := it.Value(); run.ExternalID == externalID && run.Status != CheckStatusCompleted {
+			return &run, nil
+		}
+	}
+	return nil, it.Err()
+}
+
+// complete concludes the run with its own timeout, so it still happens after the handler's context is done
+func (cr *CheckRunner) complete(run *ActiveCheckRun, conclusion CheckConclusion, output Output) error {
+	ctx, cancel := context.WithTimeout(context.Background(), completeTimeout)
+	defer cancel()
+
+	if output.Title == "" {
+		output.Title = cr.Name
+	}
+	// annotations go out in batches first, the final update then leaves the output they were sent with in place
+	var annotateErr error
+	if annotations := output.Annotations; len(annotations) > 0 {
+		output.Annotations = nil
+		if _, annotateErr = cr.Client.UpdateCheckRunAnnotations(ctx, run.Owner, run.Repo, run.ID, output, annotations, nil); annotateErr == nil {
+			output = Output{}
+		}
+	}
+	_, _, err := cr.Client.UpdateCheckRun(ctx, run.Owner, run.Repo, run.ID, CheckRun{
+		Status:      CheckStatusCompleted,
+		Conclusion:  conclusion,
+		CompletedAt: &Timestamp{time.Now()},
+		Output:      output,
+	})
+	if err == nil {
+		err = annotateErr
+	}
+	return err
+}
+
+// withSummary prepends a note to the output summary, keeping what the check reported so far
+func withSummary(output Output, title, note string) Output {
+	if output.Title == "" {
+		output.Title = title
+	}
+	if output.Summary != "" {
+		note += "\n\n" + output.Summary
+	}
+	output.Summary = note
+	return output
+}
@@ -0,0 +1,101 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// checkRunServer fakes the check runs of one commit and returns the conclusion the run was completed with
+func checkRunServer(t *testing.T) (*Client, func() CheckConclusion) {
+	t.Helper()
+	var mu sync.Mutex
+	var conclusion CheckConclusion
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count":0,"check_runs":[]}`)
+	})
+	mux.HandleFunc("/repos/o/r/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"status":"queued"}`)
+	})
+	mux.HandleFunc("/repos/o/r/check-runs/1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Conclusion CheckConclusion `json:"conclusion"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Conclusion != "" {
+			mu.Lock()
+			conclusion = body.Conclusion
+			mu.Unlock()
+		}
+		fmt.Fprint(w, `{"id":1,"status":"in_progress"}`)
+	})
+	return c, func() CheckConclusion {
+		mu.Lock()
+		defer mu.Unlock()
+		return conclusion
+	}
+}
+
+func TestCheckRunnerRun(t *testing.T) {
+	failed := errors.New("lint failed")
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		fn      CheckFunc
+		want    CheckConclusion
+		wantErr error
+	}{
+		{"success", time.Minute, func(ctx context.Context, run *ActiveCheckRun) (CheckConclusion, Output, error) {
+			return CheckConclusionSuccess, Output{Summary: "ok"}, nil
+		}, CheckConclusionSuccess, nil},
+		{"error", time.Minute, func(ctx context.Context, run *ActiveCheckRun) (CheckConclusion, Output, error) {
+			return CheckConclusionSuccess, Output{}, failed
+		}, CheckConclusionFailure, failed},
+		{"invalid conclusion", time.Minute, func(ctx context.Context, run *ActiveCheckRun) (CheckConclusion, Output, error) {
+			return "", Output{}, nil
+		}, CheckConclusionNeutral, nil},
+		{"timeout", 50 * time.Millisecond, func(ctx context.Context, run *ActiveCheckRun) (CheckConclusion, Output, error) {
+			time.Sleep(time.Second)
+			return CheckConclusionSuccess, Output{}, nil
+		}, CheckConclusionTimedOut, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, conclusion := checkRunServer(t)
+			cr := &CheckRunner{Client: c, Name: "lint"}
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			err := cr.Run(ctx, "o", "r", "abc", tt.fn)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := conclusion(); got != tt.want {
+				t.Errorf("completed as %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRunnerRunPanic(t *testing.T) {
+	c, conclusion := checkRunServer(t)
+	cr := &CheckRunner{Client: c, Name: "lint"}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("the panic did not continue")
+		}
+		if got := conclusion(); got != CheckConclusionCancelled {
+			t.Errorf("completed as %q, want cancelled", got)
+		}
+	}()
+	cr.Run(context.Background(), "o", "r", "abc", func(ctx context.Context, run *ActiveCheckRun) (CheckConclusion, Output, error) {
+		panic("boom")
+	})
+}
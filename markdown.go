@@ -0,0 +1,318 @@
+package ghclient
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxOutputLength is the most characters GitHub accepts in the summary or text of a check run output
+const MaxOutputLength = 65535
+
+// Markdown builds the summary or text of a check run output. Blocks that do not fit within the limit are cut at a
+// sensible place, a table row, a code line or a paragraph, and followed by a notice instead of making GitHub reject
+// the update. The zero value is ready to use.
+type Markdown struct {
+	// Limit is the most characters String returns, MaxOutputLength when zero
+	Limit int
+
+	blocks []markdownBlock
+}
+
+// markdownBlock renders a block whole, or shortened to at most n characters. ok is false when no useful part fits.
+type markdownBlock interface {
+	render() string
+	shorten(n int) (s string, ok bool)
+}
+
+// NewMarkdown returns an empty builder using MaxOutputLength
+func NewMarkdown() *Markdown {
+	return &Markdown{}
+}
+
+// Heading adds a heading, level is clamped to 1-6
+func (m *Markdown) Heading(level int, text string) *Markdown {
+	if level < 1 {
+		level = 1
+	}
+	if level > 6 {
+		level = 6
+	}
+	return m.add(headingBlock(strings.Repeat("#", level) + " " + singleLine(text)))
+}
+
+// Paragraph adds a paragraph of Markdown text
+func (m *Markdown) Paragraph(text string) *Markdown {
+	return m.add(textBlock(text))
+}
+
+// Paragraphf adds a paragraph formatted with fmt.Sprintf
+func (m *Markdown) Paragraphf(format string, args ...interface{}) *Markdown {
+	return m.Paragraph(fmt.Sprintf(format, args...))
+}
+
+// List adds a bullet list
+func (m *Markdown) List(items ...string) *Markdown {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "- " + singleLine(item)
+	}
+	return m.add(linesBlock{lines: lines})
+}
+
+// Table adds a table, cells are escaped so pipes and newlines do not break the layout
+func (m *Markdown) Table(header []string, rows [][]string) *Markdown {
+	t := linesBlock{}
+	t.head = append(t.head, tableRow(header))
+	sep := make([]string, len(header))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	t.head = append(t.head, "| "+strings.Join(sep, " | ")+" |")
+	for _, row := range rows {
+		t.lines = append(t.lines, tableRow(row))
+	}
+	return m.add(t)
+}
+
+// CodeBlock adds a fenced code block, the fence is made longer than any backtick run in code
+func (m *Markdown) CodeBlock(language, code string) *Markdown {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return m.add(linesBlock{
+		head:  []string{fence + language},
+		lines: strings.Split(strings.TrimRight(code, "\n"), "\n"),
+		tail:  []string{fence},
+	})
+}
+
+// Image adds an image
+func (m *Markdown) Image(alt, url string) *Markdown {
+	return m.add(headingBlock(fmt.Sprintf("![%s](%s)", escapeMarkdown(alt), url)))
+}
+
+// Details adds a collapsible section, body is shortened with the rest of the document when space runs out and may
+// be nil for an empty section
+func (m *Markdown) Details(summary string, body *Markdown) *Markdown {
+	if body == nil {
+		body = &Markdown{}
+	}
+	return m.add(detailsBlock{summary: summary, body: body})
+}
+
+// Raw adds Markdown as is
+func (m *Markdown) Raw(markdown string) *Markdown {
+	return m.add(textBlock(markdown))
+}
+
+func (m *Markdown) add(b markdownBlock) *Markdown {
+	m.blocks = append(m.blocks, b)
+	return m
+}
+
+// Len returns the length in characters of the document before truncation
+func (m *Markdown) Len() int {
+	return utf8.RuneCountInString(m.render())
+}
+
+// String returns the document, truncated with a notice when it is longer than the limit
+func (m *Markdown) String() string {
+	limit := m.Limit
+	if limit <= 0 {
+		limit = MaxOutputLength
+	}
+	s, _ := m.shorten(limit)
+	return s
+}
+
+func (m *Markdown) render() string {
+	parts := make([]string, len(m.blocks))
+	for i, b := range m.blocks {
+		parts[i] = b.render()
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// shorten keeps whole blocks while they fit, cuts the first one that does not and ends with a truncation notice
+func (m *Markdown) shorten(n int) (string, bool) {
+	if s := m.render(); utf8.RuneCountInString(s) <= n {
+		return s, true
+	}
+
+	var b strings.Builder
+	used := 0
+	for i, block := range m.blocks {
+		notice := truncatedNotice(len(m.blocks) - i)
+		room := n - used - utf8.RuneCountInString(notice)
+		if i > 0 {
+			room -= 2
+		}
+		if room <= 0 {
+			return finish(&b, notice, n, used)
+		}
+
+		s := block.render()
+		if utf8.RuneCountInString(s) > room {
+			var ok bool
+			if s, ok = block.shorten(room); !ok {
+				return finish(&b, notice, n, used)
+			}
+			// the cut block is only partly shown, it is not counted as omitted
+			notice = truncatedNotice(len(m.blocks) - i - 1)
+			if i > 0 {
+				b.WriteString("\n\n")
+				used += 2
+			}
+			b.WriteString(s)
+			return finish(&b, notice, n, used+utf8.RuneCountInString(s))
+		}
+		if i > 0 {
+			b.WriteString("\n\n")
+			used += 2
+		}
+		b.WriteString(s)
+		used += utf8.RuneCountInString(s)
+	}
+	return b.String(), true
+}
+
+// finish appends the notice when it still fits, the output is only empty when not even the notice does
+func finish(b *strings.Builder, notice string, n, used int) (string, bool) {
+	if b.Len() > 0 {
+		notice = "\n\n" + notice
+	}
+	if used+utf8.RuneCountInString(notice) <= n {
+		b.WriteString(notice)
+	}
+	return b.String(), b.Len() > 0
+}
+
+func truncatedNotice(omitted int) string {
+	switch omitted {
+	case 0:
+		return "_Output truncated to fit GitHub's size limit._"
+	case 1:
+		return "_Output truncated to fit GitHub's size limit, 1 more section was left out._"
+	}
+	return fmt.Sprintf("_Output truncated to fit GitHub's size limit, %d more sections were left out._", omitted)
+}
+
+// TruncateOutput shortens s to at most MaxOutputLength characters, ending it with a truncation notice
+func TruncateOutput(s string) string {
+	if utf8.RuneCountInString(s) <= MaxOutputLength {
+		return s
+	}
+	notice := "\n\n" + truncatedNotice(0)
+	return truncateRunes(s, MaxOutputLength-utf8.RuneCountInString(notice)) + notice
+}
+
+// headingBlock is shown whole or not at all
+type headingBlock string
+
+func (b headingBlock) render() string { return string(b) }
+
+func (b headingBlock) shorten(int) (string, bool) { return "", false }
+
+// textBlock is cut at the last line or word break that fits
+type textBlock string
+
+func (b textBlock) render() string { return string(b) }
+
+func (b textBlock) shorten(n int) (string, bool) {
+	n-- // room for the ellipsis
+	if n <= 0 {
+		return "", false
+	}
+	s := truncateRunes(string(b), n)
+	if i := strings.LastIndexAny(s, "\n "); i > len(s)/2 {
+		s = s[:i]
+	}
+	return strings.TrimRight(s, " \n") + "…", true
+}
+
+// linesBlock keeps its head and tail and drops lines from the end, so tables keep their header and code blocks
+// stay fenced
+type linesBlock struct {
+	head, lines, tail []string
+}
+
+func (b linesBlock) render() string {
+	all := append(append(append([]string(nil), b.head...), b.lines...), b.tail...)
+	return strings.Join(all, "\n")
+}
+
+func (b linesBlock) shorten(n int) (string, bool) {
+	fixed := 0
+	for _, l := range append(append([]string(nil), b.head...), b.tail...) {
+		fixed += utf8.RuneCountInString(l) + 1
+	}
+
+	kept := 0
+	used := fixed
+	for _, l := range b.lines {
+		if used+utf8.RuneCountInString(l)+1 > n {
+			break
+		}
+		used += utf8.RuneCountInString(l) + 1
+		kept++
+	}
+	if kept == 0 {
+		return "", false
+	}
+	return linesBlock{head: b.head, lines: b.lines[:kept], tail: b.tail}.render(), true
+}
+
+// detailsBlock shortens its body and keeps the element closed
+type detailsBlock struct {
+	summary string
+	body    *Markdown
+}
+
+func (b detailsBlock) open() string {
+	return "<details><summary>" + escapeHTML(b.summary) + "</summary>\n\n"
+}
+
+const detailsClose = "\n\n</details>"
+
+func (b detailsBlock) render() string {
+	return b.open() + b.body.render() + detailsClose
+}
+
+func (b detailsBlock) shorten(n int) (string, bool) {
+	room := n - utf8.RuneCountInString(b.open()) - utf8.RuneCountInString(detailsClose)
+	if room <= 0 {
+		return "", false
+	}
+	body, ok := b.body.shorten(room)
+	if !ok {
+		return "", false
+	}
+	return b.open() + body + detailsClose, true
+}
+
+func tableRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.ReplaceAll(singleLine(c), "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// singleLine replaces line breaks, which end headings, list items and table rows
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "\r", "")), " ")
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeHTML(s string) string {
+	return htmlEscaper.Replace(s)
+}
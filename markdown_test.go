@@ -0,0 +1,53 @@
+package ghclient
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarkdownDetailsNilBody(t *testing.T) {
+	m := NewMarkdown().Details("Logs", nil)
+	if s := m.String(); !strings.Contains(s, "<summary>Logs</summary>") {
+		t.Errorf("String() = %q", s)
+	}
+
+	m.Limit = 40
+	m.Paragraph(strings.Repeat("word ", 20))
+	if s := m.String(); utf8.RuneCountInString(s) > m.Limit {
+		t.Errorf("String() is %d characters, limit %d", utf8.RuneCountInString(s), m.Limit)
+	}
+}
+
+func TestMarkdownLimit(t *testing.T) {
+	m := NewMarkdown().Heading(2, "Results").Table([]string{"file", "errors"}, [][]string{
+		{"a.go", "1"}, {"b.go", "2"}, {"c.go", "3"}, {"d.go", "4"},
+	}).Paragraph(strings.Repeat("ü", 200))
+	full := m.String()
+
+	for _, limit := range []int{20, 60, 120, 200} {
+		m.Limit = limit
+		s := m.String()
+		if n := utf8.RuneCountInString(s); n > limit {
+			t.Errorf("limit %d: %d characters", limit, n)
+		}
+		if s != full && s != "" && !strings.Contains(s, "_Output truncated") {
+			t.Errorf("limit %d: no truncation notice in %q", limit, s)
+		}
+	}
+}
+
+func TestMarkdownLimitManyBlocks(t *testing.T) {
+	m := NewMarkdown().Paragraph("a").Paragraph(strings.Repeat("word ", 80))
+	for i := 0; i < 3; i++ {
+		m.Paragraph("tail")
+	}
+	m.Details("Logs", NewMarkdown().CodeBlock("", strings.Repeat("line\n", 30))).List("one", "two", "three")
+
+	for limit := 1; limit <= m.Len()+10; limit++ {
+		m.Limit = limit
+		if n := utf8.RuneCountInString(m.String()); n > limit {
+			t.Errorf("limit %d: %d characters", limit, n)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package ghclient
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable fields in the models are pointers, nil means GitHub sent null or omitted the field and the two cannot be
+// told apart. Fields where the difference matters, such as PullRequest.Mergeable, use Nullable instead.
+
+// Ptr returns a pointer to v, used to populate nullable fields
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Value returns the value p points to, or the zero value of T when p is nil
+func Value[T any](p *T) T {
+	var v T
+	if p != nil {
+		v = *p
+	}
+	return v
+}
+
+// Nullable is a JSON value that tells a null value apart from an absent one. A Nullable that was absent encodes
+// as null, the models always emit every field.
+type Nullable[T any] struct {
+	Value T
+	// Valid is true when the field holds a value, false when it was null or absent
+	Valid bool
+	// Set is true when the field was present in the JSON, null included
+	Set bool
+}
+
+// NullableOf returns a Nullable holding v
+func NullableOf[T any](v T) Nullable[T] {
+	return Nullable[T]{Value: v, Valid: true, Set: true}
+}
+
+// Null returns a Nullable that was explicitly set to null
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{Set: true}
+}
+
+// Get returns the value and whether there is one
+func (n Nullable[T]) Get() (T, bool) {
+	return n.Value, n.Valid
+}
+
+// Ptr returns a pointer to the value, nil when there is none
+func (n Nullable[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	return Ptr(n.Value)
+}
+
+// UnmarshalJSON is only called for fields present in the JSON, which is what Set records
+func (n *Nullable[T]) UnmarshalJSON(b []byte) error {
+	*n = Nullable[T]{Set: true}
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.Value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON encodes the value, or null when there is none
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
@@ -0,0 +1,148 @@
+package ghclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MaxPerPage is the largest page size the REST API accepts
+const MaxPerPage = 100
+
+// ListOptions selects a page of a list endpoint, it is embedded in the options of every list call
+type ListOptions struct {
+	// Page is the 1-based page to fetch, the first page when zero
+	Page int
+	// PerPage is the page size, GitHub's default of 30 when zero, capped at MaxPerPage
+	PerPage int
+}
+
+// addValues sets the page parameters on v
+func (o ListOptions) addValues(v url.Values) {
+	if o.Page > 0 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		perPage := o.PerPage
+		if perPage > MaxPerPage {
+			perPage = MaxPerPage
+		}
+		v.Set("per_page", strconv.Itoa(perPage))
+	}
+}
+
+// parseLinks fills the page numbers of r from its Link header
+func (r *Response) parseLinks() {
+	for _, link := range strings.Split(r.Header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(parts[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		u, err := url.Parse(target[1 : len(target)-1])
+		if err != nil {
+			continue
+		}
+		page, _ := strconv.Atoi(u.Query().Get("page"))
+
+		for _, param := range parts[1:] {
+			switch strings.TrimSpace(param) {
+			case `rel="next"`:
+				r.NextPage, r.NextURL = page, u.String()
+			case `rel="prev"`:
+				r.PrevPage = page
+			case `rel="first"`:
+				r.FirstPage = page
+			case `rel="last"`:
+				r.LastPage = page
+			}
+		}
+	}
+}
+
+// PageFunc fetches one page of a list
+type PageFunc[T any] func(ctx context.Context, page ListOptions) ([]T, *Response, error)
+
+// Iterator walks every item of a list endpoint, fetching the next page only when the current one is used up.
+// Call Next until it returns false, then check Err:
+//
+//	it := client.IterateCheckRunsForRef(owner, repo, sha, nil)
+//	for it.Next(ctx) {
+//		run := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	fetch PageFunc[T]
+	page  ListOptions
+
+	items []T
+	value T
+	resp  *Response
+	err   error
+	done  bool
+}
+
+// NewIterator returns an iterator starting at the page selected by opts, which also sets the page size
+func NewIterator[T any](opts ListOptions, fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, page: opts}
+}
+
+// Next advances to the next item, fetching a page when needed. It returns false when the list is exhausted,
+// ctx is done or a request failed.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.err = ctx.Err(); it.err != nil {
+			return false
+		}
+
+		items, resp, err := it.fetch(ctx, it.page)
+		it.resp = resp
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = items
+		if resp == nil || resp.NextPage == 0 {
+			it.done = true
+		} else {
+			it.page.Page = resp.NextPage
+		}
+	}
+
+	it.value = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response of the last page fetched
+func (it *Iterator[T]) Response() *Response {
+	return it.resp
+}
+
+// All collects the remaining items
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
@@ -0,0 +1,92 @@
+package ghclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestParseLinks(t *testing.T) {
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("Link", `<https://api.github.com/repositories/1/issues?page=3&per_page=2>; rel="next", `+
+		`<https://api.github.com/repositories/1/issues?page=1&per_page=2>; rel="prev", `+
+		`<https://api.github.com/repositories/1/issues?page=1&per_page=2>; rel="first", `+
+		`<https://api.github.com/repositories/1/issues?page=5&per_page=2>; rel="last"`)
+	resp.parseLinks()
+
+	if resp.NextPage != 3 || resp.PrevPage != 1 || resp.FirstPage != 1 || resp.LastPage != 5 {
+		t.Errorf("pages = next %d prev %d first %d last %d", resp.NextPage, resp.PrevPage, resp.FirstPage, resp.LastPage)
+	}
+	if want := "https://api.github.com/repositories/1/issues?page=3&per_page=2"; resp.NextURL != want {
+		t.Errorf("NextURL = %s, want %s", resp.NextURL, want)
+	}
+}
+
+func TestParseLinksCursor(t *testing.T) {
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("Link", `<https://api.github.com/orgs/o/audit-log?after=MS42&before=>; rel="next", garbage, <>; rel`)
+	resp.parseLinks()
+
+	if resp.NextPage != 0 {
+		t.Errorf("NextPage = %d, want 0 for a cursor link", resp.NextPage)
+	}
+	if resp.NextURL == "" {
+		t.Error("NextURL is empty")
+	}
+}
+
+func TestIterator(t *testing.T) {
+	c, mux := setup(t, nil)
+	const pages = 3
+	mux.HandleFunc("/repos/o/r/pulls/1/files", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if got := r.URL.Query().Get("per_page"); got != "2" {
+			t.Errorf("per_page = %s, want 2", got)
+		}
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d&per_page=2>; rel="next"`, r.URL.Path, page+1))
+		}
+		fmt.Fprintf(w, `[{"filename":"%d-a"},{"filename":"%d-b"}]`, page, page)
+	})
+
+	it := c.IteratePullRequestFiles("o", "r", 1, &ListOptions{PerPage: 2})
+	files, err := it.All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2*pages || files[0].Filename != "1-a" || files[len(files)-1].Filename != "3-b" {
+		t.Errorf("files = %+v", files)
+	}
+	if it.Next(context.Background()) {
+		t.Error("Next after the last page = true")
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/pulls/1/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Link", `<`+r.URL.Path+`?page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"filename":"a"}]`)
+	})
+
+	it := c.IteratePullRequestFiles("o", "r", 1, nil)
+	files, err := it.All(context.Background())
+	if err == nil {
+		t.Fatal("expected the error of the second page")
+	}
+	if len(files) != 1 {
+		t.Errorf("files = %+v, want the first page", files)
+	}
+	if it.Response() == nil || it.Response().StatusCode != http.StatusInternalServerError {
+		t.Errorf("Response() = %+v", it.Response())
+	}
+}
@@ -0,0 +1,97 @@
+package ghclient
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PermissionLevel is the access an installation holds for a permission scope
+type PermissionLevel string
+
+// Permission levels granted to GitHub Apps, each level includes the ones before it
+const (
+	PermissionNone  PermissionLevel = ""
+	PermissionRead  PermissionLevel = "read"
+	PermissionWrite PermissionLevel = "write"
+	PermissionAdmin PermissionLevel = "admin"
+)
+
+func (l PermissionLevel) rank() int {
+	switch l {
+	case PermissionRead:
+		return 1
+	case PermissionWrite:
+		return 2
+	case PermissionAdmin:
+		return 3
+	}
+	return 0
+}
+
+// IsValid reports whether the level is one GitHub grants
+func (l PermissionLevel) IsValid() bool {
+	return l.rank() > 0
+}
+
+// Includes reports whether holding level l also grants level o
+func (l PermissionLevel) Includes(o PermissionLevel) bool {
+	return l.rank() >= o.rank()
+}
+
+// permissionFields maps a permission scope, as named in the JSON payload, to its field index in Permissions
+var permissionFields = func() map[string]int {
+	fields := map[string]int{}
+	t := reflect.TypeOf(Permissions{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		fields[name] = i
+	}
+	return fields
+}()
+
+// Level returns the level held for the scope, scope is the permission name used by GitHub (e.g. "pull_requests")
+func (p Permissions) Level(scope string) PermissionLevel {
+	i, ok := permissionFields[scope]
+	if !ok {
+		return PermissionNone
+	}
+	return reflect.ValueOf(p).Field(i).Interface().(PermissionLevel)
+}
+
+// Allows reports whether the permissions grant at least level for the scope
+func (p Permissions) Allows(scope string, level PermissionLevel) bool {
+	if _, ok := permissionFields[scope]; !ok {
+		return false
+	}
+	return p.Level(scope).Includes(level)
+}
+
+// Require checks every scope set in required is granted, and returns an error listing the ones that are not
+func (p Permissions) Require(required Permissions) error {
+	var missing []string
+	for scope := range permissionFields {
+		want := required.Level(scope)
+		if want == PermissionNone {
+			continue
+		}
+		if !p.Allows(scope, want) {
+			missing = append(missing, fmt.Sprintf("%s:%s", scope, want))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return &PermissionError{Missing: missing}
+}
+
+// PermissionError is returned when an installation lacks permissions a handler needs
+type PermissionError struct {
+	Missing []string
+}
+
+func (e *PermissionError) Error() string {
+	return "ghclient: installation is missing permissions " + strings.Join(e.Missing, ", ")
+}
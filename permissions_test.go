@@ -0,0 +1,126 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPermissionLevelIncludes(t *testing.T) {
+	levels := []PermissionLevel{PermissionNone, PermissionRead, PermissionWrite, PermissionAdmin}
+	for i, held := range levels {
+		for j, want := range levels {
+			if got := held.Includes(want); got != (i >= j) {
+				t.Errorf("%q.Includes(%q) = %v", held, want, got)
+			}
+		}
+	}
+	if PermissionLevel("owner").IsValid() || PermissionNone.IsValid() || !PermissionAdmin.IsValid() {
+		t.Error("IsValid accepts the wrong levels")
+	}
+}
+
+func TestPermissionsLevel(t *testing.T) {
+	// scope names as GitHub sends them, a mistyped JSON tag makes the strict decode or the lookup fail
+	granted := map[string]PermissionLevel{
+		"actions":                     PermissionRead,
+		"administration":              PermissionAdmin,
+		"checks":                      PermissionWrite,
+		"contents":                    PermissionRead,
+		"issues":                      PermissionWrite,
+		"members":                     PermissionRead,
+		"metadata":                    PermissionRead,
+		"organization_administration": PermissionRead,
+		"pull_requests":               PermissionWrite,
+		"secret_scanning_alerts":      PermissionRead,
+		"single_file":                 PermissionWrite,
+		"statuses":                    PermissionWrite,
+		"workflows":                   PermissionWrite,
+	}
+	payload, _ := json.Marshal(granted)
+	var p Permissions
+	if err := Decode(payload, &p, DisallowUnknownFields()); err != nil {
+		t.Fatal(err)
+	}
+	for scope, want := range granted {
+		if got := p.Level(scope); got != want {
+			t.Errorf("Level(%q) = %q, want %q", scope, got, want)
+		}
+	}
+	if got := p.Level("deployments"); got != PermissionNone {
+		t.Errorf("Level of a scope not granted = %q", got)
+	}
+	if got := p.Level("pullrequests"); got != PermissionNone {
+		t.Errorf("Level of an unknown scope = %q", got)
+	}
+
+	// every field is reachable through its JSON name
+	v := reflect.ValueOf(&p).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).Set(reflect.ValueOf(PermissionAdmin))
+	}
+	if len(permissionFields) != v.NumField() {
+		t.Fatalf("%d scopes for %d fields, two fields share a JSON name", len(permissionFields), v.NumField())
+	}
+	for scope := range permissionFields {
+		if p.Level(scope) != PermissionAdmin {
+			t.Errorf("Level(%q) does not read its field", scope)
+		}
+	}
+}
+
+func TestPermissionsAllows(t *testing.T) {
+	p := Permissions{Checks: PermissionWrite, Contents: PermissionRead, Administration: PermissionAdmin}
+	tests := []struct {
+		scope string
+		level PermissionLevel
+		want  bool
+	}{
+		{"checks", PermissionRead, true},
+		{"checks", PermissionWrite, true},
+		{"checks", PermissionAdmin, false},
+		{"contents", PermissionRead, true},
+		{"contents", PermissionWrite, false},
+		{"administration", PermissionWrite, true},
+		{"issues", PermissionRead, false},
+		{"issues", PermissionNone, true},
+		// unknown scopes are never allowed, even when nothing is asked for
+		{"check", PermissionNone, false},
+		{"", PermissionRead, false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.scope, tt.level); got != tt.want {
+			t.Errorf("Allows(%q, %q) = %v, want %v", tt.scope, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestPermissionsRequire(t *testing.T) {
+	p := Permissions{Checks: PermissionWrite, Contents: PermissionRead, Metadata: PermissionRead}
+
+	if err := p.Require(Permissions{Checks: PermissionRead, Metadata: PermissionRead}); err != nil {
+		t.Errorf("Require of granted scopes = %v", err)
+	}
+	if err := p.Require(Permissions{}); err != nil {
+		t.Errorf("Require of nothing = %v", err)
+	}
+
+	err := p.Require(Permissions{
+		Statuses:     PermissionWrite,
+		Checks:       PermissionWrite,
+		Contents:     PermissionWrite,
+		PullRequests: PermissionRead,
+	})
+	var perr *PermissionError
+	if !errors.As(err, &perr) {
+		t.Fatalf("err = %v, want a *PermissionError", err)
+	}
+	want := []string{"contents:write", "pull_requests:read", "statuses:write"}
+	if !reflect.DeepEqual(perr.Missing, want) {
+		t.Errorf("Missing = %v, want %v", perr.Missing, want)
+	}
+	if msg := perr.Error(); msg != "ghclient: installation is missing permissions contents:write, pull_requests:read, statuses:write" {
+		t.Errorf("Error() = %q", msg)
+	}
+}
@@ -0,0 +1,216 @@
+package ghclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	mediaTypeDiff  = "application/vnd.github.diff"
+	mediaTypePatch = "application/vnd.github.patch"
+)
+
+func pullPath(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/pulls/%d", repoPath(owner, repo), number)
+}
+
+// GetPullRequest returns a single pull request, Mergeable is null, so not Valid, while GitHub is still computing it
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*PullRequest, *Response, error) {
+	return c.sendPullRequest(ctx, http.MethodGet, pullPath(owner, repo, number), nil)
+}
+
+// PullRequestUpdate holds the fields to change on a pull request, nil fields are left as they are
+type PullRequestUpdate struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+	// State is "open" or "closed"
+	State *string `json:"state,omitempty"`
+	// Base is the branch the pull request merges into
+	Base                *string `json:"base,omitempty"`
+	MaintainerCanModify *bool   `json:"maintainer_can_modify,omitempty"`
+}
+
+// UpdatePullRequest changes the title, body, state or base branch of a pull request
+func (c *Client) UpdatePullRequest(ctx context.Context, owner, repo string, number int, update PullRequestUpdate) (*PullRequest, *Response, error) {
+	return c.sendPullRequest(ctx, http.MethodPatch, pullPath(owner, repo, number), update)
+}
+
+func (c *Client) sendPullRequest(ctx context.Context, method, path string, body interface{}) (*PullRequest, *Response, error) {
+	req, err := c.NewRequest(method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pr := &PullRequest{}
+	resp, err := c.Do(ctx, req, pr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return pr, resp, nil
+}
+
+// ListPullRequestFiles lists a page of the files a pull request changes, GitHub returns at most 3000 files
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]CommitFile, *Response, error) {
+	var files []CommitFile
+	resp, err := c.list(ctx, pullPath(owner, repo, number)+"/files", opts, &files)
+	return files, resp, err
+}
+
+// IteratePullRequestFiles walks every file a pull request changes
+func (c *Client) IteratePullRequestFiles(owner, repo string, number int, opts *ListOptions) *Iterator[CommitFile] {
+	return NewIterator(pageOf(opts), func(ctx context.Context, page ListOptions) ([]CommitFile, *Response, error) {
+		return c.ListPullRequestFiles(ctx, owner, repo, number, &page)
+	})
+}
+
+// ListPullRequestCommits lists a page of the commits of a pull request, GitHub returns at most 250 commits
+func (c *Client) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]RepositoryCommit, *Response, error) {
+	var commits []RepositoryCommit
+	resp, err := c.list(ctx, pullPath(owner, repo, number)+"/commits", opts, &commits)
+	return commits, resp, err
+}
+
+// IteratePullRequestCommits walks every commit of a pull request
+func (c *Client) IteratePullRequestCommits(owner, repo string, number int, opts *ListOptions) *Iterator[RepositoryCommit] {
+	return NewIterator(pageOf(opts), func(ctx context.Context, page ListOptions) ([]RepositoryCommit, *Response, error) {
+		return c.ListPullRequestCommits(ctx, owner, repo, number, &page)
+	})
+}
+
+// list fetches a page of a list endpoint returning a plain JSON array into v
+func (c *Client) list(ctx context.Context, path string, opts *ListOptions, v interface{}) (*Response, error) {
+	q := url.Values{}
+	if opts != nil {
+		opts.addValues(q)
+	}
+	req, err := c.NewRequest(http.MethodGet, withQuery(path, q), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, v)
+}
+
+// pageOf returns the page options to start an iterator with
+func pageOf(opts *ListOptions) ListOptions {
+	if opts == nil {
+		return ListOptions{}
+	}
+	return *opts
+}
+
+// GetPullRequestDiff returns the pull request as a unified diff
+func (c *Client) GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, *Response, error) {
+	return c.getPullRequestAs(ctx, owner, repo, number, mediaTypeDiff)
+}
+
+// GetPullRequestPatch returns the commits of the pull request in git format-patch format
+func (c *Client) GetPullRequestPatch(ctx context.Context, owner, repo string, number int) (string, *Response, error) {
+	return c.getPullRequestAs(ctx, owner, repo, number, mediaTypePatch)
+}
+
+func (c *Client) getPullRequestAs(ctx context.Context, owner, repo string, number int, mediaType string) (string, *Response, error) {
+	req, err := c.NewRequest(http.MethodGet, pullPath(owner, repo, number), nil)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+
+	var buf bytes.Buffer
+	resp, err := c.Do(ctx, req, &buf)
+	if err != nil {
+		return "", resp, err
+	}
+	return buf.String(), resp, nil
+}
+
+// DraftReviewComment is an inline comment submitted with a review
+type DraftReviewComment struct {
+	Path string `json:"path"`
+	Body string `json:"body"`
+	// Line is the line of the file the comment applies to, the last line for multi-line comments
+	Line int `json:"line,omitempty"`
+	// Side is LEFT for deleted lines and RIGHT, the default, for added or unchanged lines
+	Side string `json:"side,omitempty"`
+	// StartLine and StartSide mark the first line of a multi-line comment
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+}
+
+// ReviewRequest is a review to submit on a pull request
+type ReviewRequest struct {
+	// CommitID is the commit the review applies to, the latest commit of the pull request when empty
+	CommitID string `json:"commit_id,omitempty"`
+	Body     string `json:"body,omitempty"`
+	// Event submits the review, an empty event leaves it pending
+	Event    ReviewEvent          `json:"event,omitempty"`
+	Comments []DraftReviewComment `json:"comments,omitempty"`
+}
+
+// CreateReview submits a review with optional inline comments on a pull request
+func (c *Client) CreateReview(ctx context.Context, owner, repo string, number int, review ReviewRequest) (*Review, *Response, error) {
+	if review.Event != "" && !review.Event.IsValid() {
+		return nil, nil, fmt.Errorf("ghclient: invalid review event %q", review.Event)
+	}
+	req, err := c.NewRequest(http.MethodPost, pullPath(owner, repo, number)+"/reviews", review)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &Review{}
+	resp, err := c.Do(ctx, req, r)
+	if err != nil {
+		return nil, resp, err
+	}
+	return r, resp, nil
+}
+
+// ListReviewComments lists a page of the inline review comments of a pull request
+func (c *Client) ListReviewComments(ctx context.Context, owner, repo string, number int, opts *ListOptions) ([]PullRequestComment, *Response, error) {
+	var comments []PullRequestComment
+	resp, err := c.list(ctx, pullPath(owner, repo, number)+"/comments", opts, &comments)
+	return comments, resp, err
+}
+
+// RequestReviewers asks users, by login, and teams, by slug, to review a pull request
+func (c *Client) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers, teamReviewers []string) (*PullRequest, *Response, error) {
+	body := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{reviewers, teamReviewers}
+	return c.sendPullRequest(ctx, http.MethodPost, pullPath(owner, repo, number)+"/requested_reviewers", body)
+}
+
+// MergeOptions controls how a pull request is merged
+type MergeOptions struct {
+	// Method is merge, squash or rebase, the repository default when empty
+	Method        MergeMethod `json:"merge_method,omitempty"`
+	CommitTitle   string      `json:"commit_title,omitempty"`
+	CommitMessage string      `json:"commit_message,omitempty"`
+	// Sha must match the head of the pull request for the merge to happen, so commits pushed after it was
+	// checked are never merged unseen. GitHub answers 409 Conflict when it does not match.
+	Sha string `json:"sha,omitempty"`
+}
+
+// MergePullRequest merges a pull request
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, number int, opts *MergeOptions) (*PullRequestMergeResult, *Response, error) {
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+	if opts.Method != "" && !opts.Method.IsValid() {
+		return nil, nil, fmt.Errorf("ghclient: invalid merge method %q", opts.Method)
+	}
+	req, err := c.NewRequest(http.MethodPut, pullPath(owner, repo, number)+"/merge", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &PullRequestMergeResult{}
+	resp, err := c.Do(ctx, req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result, resp, nil
+}
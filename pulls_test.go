@@ -0,0 +1,156 @@
+package ghclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetPullRequestDiffAndPatch(t *testing.T) {
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "accept %s", r.Header.Get("Accept"))
+	})
+
+	diff, _, err := c.GetPullRequestDiff(context.Background(), "o", "r", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "accept application/vnd.github.diff" {
+		t.Errorf("diff = %q", diff)
+	}
+	patch, _, err := c.GetPullRequestPatch(context.Background(), "o", "r", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != "accept application/vnd.github.patch" {
+		t.Errorf("patch = %q", patch)
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	const head = "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	var sent map[string]string
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/pulls/7/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s", r.Method)
+		}
+		sent = nil
+		json.NewDecoder(r.Body).Decode(&sent)
+		if sent["sha"] != "" && sent["sha"] != head {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message":"Head branch was modified. Review and try the merge again."}`)
+			return
+		}
+		fmt.Fprint(w, `{"sha":"e5bd3914e2e596debea16f433f57875b5b90bcd6","merged":true,"message":"Pull Request successfully merged"}`)
+	})
+
+	result, _, err := c.MergePullRequest(context.Background(), "o", "r", 7, &MergeOptions{Method: MergeMethodSquash, Sha: head})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Merged {
+		t.Errorf("result = %+v", result)
+	}
+	if sent["merge_method"] != "squash" || sent["sha"] != head {
+		t.Errorf("body = %v", sent)
+	}
+
+	// a head that moved since it was checked is not merged
+	_, resp, err := c.MergePullRequest(context.Background(), "o", "r", 7, &MergeOptions{Sha: "0000000000000000000000000000000000000000"})
+	var eresp *ErrorResponse
+	if !errors.As(err, &eresp) || resp.StatusCode != http.StatusConflict {
+		t.Errorf("err = %v, want a 409 *ErrorResponse", err)
+	}
+
+	// nil options send neither a method nor a SHA
+	if _, _, err := c.MergePullRequest(context.Background(), "o", "r", 7, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent) != 0 {
+		t.Errorf("body = %v, want {}", sent)
+	}
+
+	sent = map[string]string{"untouched": "true"}
+	if _, _, err := c.MergePullRequest(context.Background(), "o", "r", 7, &MergeOptions{Method: "fast-forward"}); err == nil {
+		t.Error("an invalid merge method was accepted")
+	}
+	if sent["untouched"] != "true" {
+		t.Error("an invalid merge method was sent")
+	}
+}
+
+func TestCreateReviewValidatesEvent(t *testing.T) {
+	var requests int
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/pulls/7/reviews", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"id":80}`)
+	})
+
+	for _, event := range []ReviewEvent{"", ReviewEventApprove, ReviewEventRequestChanges, ReviewEventComment} {
+		if _, _, err := c.CreateReview(context.Background(), "o", "r", 7, ReviewRequest{Event: event}); err != nil {
+			t.Errorf("event %q: %s", event, err)
+		}
+	}
+	if _, _, err := c.CreateReview(context.Background(), "o", "r", 7, ReviewRequest{Event: "approve"}); err == nil {
+		t.Error("a lower-case event was accepted")
+	}
+	if requests != 4 {
+		t.Errorf("%d requests, want 4", requests)
+	}
+}
+
+const pullRequestCommits = `[{
+	"sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+	"node_id": "MDY6Q29tbWl0NmRjYjA5YjViNTc4NzVmMzM0ZjYxYWViZWQ2OTVlMmU0MTkzZGI1ZQ==",
+	"commit": {
+		"url": "https://api.github.com/repos/o/r/git/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		"author": {"name": "Monalisa Octocat", "email": "support@github.com", "date": "2011-04-14T16:00:49Z"},
+		"committer": {"name": "Monalisa Octocat", "email": "support@github.com", "date": "2011-04-14T16:00:49Z"},
+		"message": "Fix all the bugs",
+		"tree": {"url": "https://api.github.com/repos/o/r/tree/6dcb09b5b57875f334f61aebed695e2e4193db5e", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"},
+		"comment_count": 0,
+		"verification": {"verified": false, "reason": "unsigned", "signature": null, "payload": null, "verified_at": null}
+	},
+	"url": "https://api.github.com/repos/o/r/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+	"html_url": "https://github.com/o/r/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+	"comments_url": "https://api.github.com/repos/o/r/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/comments",
+	"author": {"login": "octocat", "id": 1, "type": "User"},
+	"committer": null,
+	"parents": [{"url": "https://api.github.com/repos/o/r/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e", "sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}],
+	"stats": {"additions": 104, "deletions": 4, "total": 108},
+	"files": [{"filename": "file1.txt", "status": "added", "additions": 103, "deletions": 21, "changes": 124, "patch": "@@ -29,7 +29,7 @@"}]
+}]`
+
+func TestListPullRequestCommits(t *testing.T) {
+	c, mux := setup(t, nil)
+	mux.HandleFunc("/repos/o/r/pulls/7/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pullRequestCommits)
+	})
+
+	commits, _, err := c.ListPullRequestCommits(context.Background(), "o", "r", 7, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Decode([]byte(pullRequestCommits), &[]RepositoryCommit{}, DisallowUnknownFields()); err != nil {
+		t.Error(err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("%d commits", len(commits))
+	}
+	commit := commits[0]
+	if commit.Commit.Message != "Fix all the bugs" || commit.Commit.Author.Email != "support@github.com" || commit.Commit.Verification.Reason != "unsigned" {
+		t.Errorf("commit = %+v", commit.Commit)
+	}
+	if commit.Author == nil || commit.Author.Login != "octocat" || commit.Committer != nil {
+		t.Errorf("author = %v, committer = %v", commit.Author, commit.Committer)
+	}
+	if commit.Stats == nil || commit.Stats.Total != 108 || len(commit.Files) != 1 || commit.Files[0].Status != FileStatusAdded {
+		t.Errorf("stats = %v, files = %v", commit.Stats, commit.Files)
+	}
+}
@@ -0,0 +1,212 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secondaryRateLimitWait is how long GitHub asks to wait after a secondary rate limit without a Retry-After header
+const secondaryRateLimitWait = time.Minute
+
+// Rate is the primary rate limit of one API resource, as reported by the X-RateLimit-* headers
+type Rate struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     Timestamp `json:"reset"`
+	// Resource is the bucket the limit applies to, such as core, search or graphql
+	Resource string `json:"resource"`
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%s %d/%d, resets at %s", r.Resource, r.Remaining, r.Limit, r.Reset.Format(time.RFC3339))
+}
+
+// parseRate reads the rate limit headers, the zero Rate is returned when they are missing
+func parseRate(h http.Header) Rate {
+	var r Rate
+	if r.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit")); r.Limit == 0 {
+		return Rate{}
+	}
+	r.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	r.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		r.Reset = Timestamp{time.Unix(reset, 0)}
+	}
+	r.Resource = h.Get("X-RateLimit-Resource")
+	if r.Resource == "" {
+		r.Resource = "core"
+	}
+	return r
+}
+
+// parseRetryAfter reads the Retry-After header, given in seconds or as an HTTP date
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// RateLimits holds the primary rate limits of each resource returned by GetRateLimits
+type RateLimits struct {
+	Resources map[string]Rate `json:"resources"`
+}
+
+// GetRateLimits returns the current limits of the credentials the client uses, the call itself is not counted.
+// Each installation has its own limits, so ask with a client using that installation's transport.
+func (c *Client) GetRateLimits(ctx context.Context) (*RateLimits, *Response, error) {
+	req, err := c.NewRequest(http.MethodGet, "rate_limit", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	limits := &RateLimits{}
+	resp, err := c.Do(ctx, req, limits)
+	if err != nil {
+		return nil, resp, err
+	}
+	for name, rate := range limits.Resources {
+		rate.Resource = name
+		limits.Resources[name] = rate
+		c.recordRate(rate)
+	}
+	return limits, resp, nil
+}
+
+// Rate returns the last limit the client saw for a resource, "core" for most endpoints. The zero Rate is returned
+// before any response for the resource arrived.
+func (c *Client) Rate(resource string) Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rates[resource]
+}
+
+func (c *Client) recordRate(r Rate) {
+	if r.Limit == 0 {
+		return
+	}
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	if c.rates == nil {
+		c.rates = map[string]Rate{}
+	}
+	c.rates[r.Resource] = r
+}
+
+// rateResource guesses the resource a request counts against before a response says so
+func rateResource(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.Contains(path, "/search/code"):
+		return "code_search"
+	case strings.Contains(path, "/search/"):
+		return "search"
+	case strings.HasSuffix(path, "/graphql"):
+		return "graphql"
+	}
+	return "core"
+}
+
+// checkRateLimit fails without sending the request while a known limit is used up
+func (c *Client) checkRateLimit(req *http.Request) error {
+	rate := c.Rate(rateResource(req))
+	if rate.Limit == 0 || rate.Remaining > 0 || !time.Now().Before(rate.Reset.Time) {
+		return nil
+	}
+	return &RateLimitError{
+		Rate:    rate,
+		Message: fmt.Sprintf("API rate limit of %d still exceeded, not sending the request", rate.Limit),
+	}
+}
+
+// RateLimitError is returned when the primary rate limit of a resource is used up
+type RateLimitError struct {
+	Rate Rate
+	// Response is nil when the client did not send the request because the limit was known to be used up
+	Response *http.Response
+	Message  string
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%s, resets in %s", e.Message, time.Until(e.Rate.Reset.Time).Round(time.Second))
+	if e.Response == nil || e.Response.Request == nil {
+		return "ghclient: " + msg
+	}
+	return fmt.Sprintf("ghclient: %s %s: %d %s", e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, msg)
+}
+
+// AbuseRateLimitError is returned when GitHub's secondary rate limits reject a burst of requests
+type AbuseRateLimitError struct {
+	Response *http.Response
+	Message  string
+	// RetryAfter is how long GitHub asked to wait, zero when it did not say
+	RetryAfter time.Duration
+}
+
+func (e *AbuseRateLimitError) Error() string {
+	msg := e.Message
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+	if e.Response == nil || e.Response.Request == nil {
+		return "ghclient: " + msg
+	}
+	return fmt.Sprintf("ghclient: %s %s: %d %s", e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, msg)
+}
+
+// rateLimitError turns an error response caused by a rate limit into the matching typed error
+func rateLimitError(e *ErrorResponse) error {
+	r := e.Response
+	if r.StatusCode != http.StatusForbidden && r.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	retryAfter := parseRetryAfter(r.Header)
+	if retryAfter > 0 || strings.Contains(e.Message, "secondary rate limit") || strings.Contains(e.DocumentationURL, "secondary-rate-limits") {
+		return &AbuseRateLimitError{Response: r, Message: e.Message, RetryAfter: retryAfter}
+	}
+	if rate := parseRate(r.Header); rate.Limit > 0 && rate.Remaining == 0 {
+		return &RateLimitError{Rate: rate, Response: r, Message: e.Message}
+	}
+	return nil
+}
+
+// rateLimitWait returns how long to wait before retrying after err, ok is false when err is not a rate limit
+func rateLimitWait(err error) (wait time.Duration, ok bool) {
+	var primary *RateLimitError
+	var secondary *AbuseRateLimitError
+	switch {
+	case errors.As(err, &primary):
+		return time.Until(primary.Rate.Reset.Time) + time.Second, true
+	case errors.As(err, &secondary):
+		if secondary.RetryAfter > 0 {
+			return secondary.RetryAfter, true
+		}
+		// without Retry-After GitHub asks to wait for the reset when the limit is used up, a minute otherwise
+		if rate := parseRate(secondary.Response.Header); rate.Limit > 0 && rate.Remaining == 0 {
+			return time.Until(rate.Reset.Time) + time.Second, true
+		}
+		return secondaryRateLimitWait, true
+	}
+	return 0, false
+}
+
+// waitForRateLimit sleeps until the request may be retried after err, it returns false when err is not a rate
+// limit or the wait would not end before ctx does
+func waitForRateLimit(ctx context.Context, err error) bool {
+	wait, ok := rateLimitWait(err)
+	return ok && sleep(ctx, wait)
+}
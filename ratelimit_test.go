@@ -0,0 +1,115 @@
+package ghclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitError(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		want    string
+	}{
+		{"primary", http.StatusForbidden,
+			map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset},
+			`{"message":"API rate limit exceeded for installation ID 1."}`, "primary"},
+		{"primary 429", http.StatusTooManyRequests,
+			map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset},
+			`{"message":"API rate limit exceeded"}`, "primary"},
+		{"secondary retry-after", http.StatusForbidden,
+			map[string]string{"Retry-After": "30", "X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "4000"},
+			`{"message":"You have exceeded a secondary rate limit."}`, "secondary"},
+		{"secondary message", http.StatusForbidden, nil,
+			`{"message":"You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`, "secondary"},
+		{"secondary documentation", http.StatusForbidden, nil,
+			`{"message":"slow down","documentation_url":"https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`, "secondary"},
+		{"forbidden", http.StatusForbidden,
+			map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "4999"},
+			`{"message":"Resource not accessible by integration"}`, "error"},
+		{"not found", http.StatusNotFound,
+			map[string]string{"Retry-After": "30"},
+			`{"message":"Not Found"}`, "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux := setup(t, nil)
+			mux.HandleFunc("/rate", func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			req, _ := c.NewRequest(http.MethodGet, "rate", nil)
+			_, err := c.Do(context.Background(), req, nil)
+
+			var primary *RateLimitError
+			var secondary *AbuseRateLimitError
+			var other *ErrorResponse
+			switch {
+			case errors.As(err, &primary):
+				if tt.want != "primary" {
+					t.Errorf("got a primary rate limit error, want %s: %v", tt.want, err)
+				}
+				if primary.Rate.Limit != 5000 || primary.Rate.Remaining != 0 {
+					t.Errorf("Rate = %+v", primary.Rate)
+				}
+			case errors.As(err, &secondary):
+				if tt.want != "secondary" {
+					t.Errorf("got a secondary rate limit error, want %s: %v", tt.want, err)
+				}
+				if tt.headers["Retry-After"] != "" && secondary.RetryAfter != 30*time.Second {
+					t.Errorf("RetryAfter = %s, want 30s", secondary.RetryAfter)
+				}
+			case errors.As(err, &other):
+				if tt.want != "error" {
+					t.Errorf("got an error response, want %s: %v", tt.want, err)
+				}
+			default:
+				t.Errorf("err = %#v", err)
+			}
+		})
+	}
+}
+
+func TestRateLimitKnownExhausted(t *testing.T) {
+	c, mux := setup(t, nil)
+	var requests int
+	mux.HandleFunc("/rate", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		fmt.Fprint(w, `{}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		req, _ := c.NewRequest(http.MethodGet, "rate", nil)
+		_, err := c.Do(context.Background(), req, nil)
+		if i == 0 && err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			var primary *RateLimitError
+			if !errors.As(err, &primary) || primary.Response != nil {
+				t.Errorf("err = %v, want a RateLimitError without a response", err)
+			}
+		}
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+	if rate := c.Rate("core"); rate.Limit != 60 {
+		t.Errorf("Rate(core) = %+v", rate)
+	}
+}
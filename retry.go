@@ -0,0 +1,143 @@
+package ghclient
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Defaults of RetryTransport
+const (
+	DefaultMaxAttempts = 3
+	DefaultMinBackoff  = 500 * time.Millisecond
+	DefaultMaxBackoff  = 30 * time.Second
+)
+
+// RetryEvent describes a failed attempt RetryTransport is about to retry
+type RetryEvent struct {
+	Request *http.Request
+	// Attempt is the 1-based number of the attempt that failed
+	Attempt int
+	// Response is the failed response, nil when the request failed without one
+	Response *http.Response
+	Err      error
+	// Wait is how long the transport sleeps before the next attempt
+	Wait time.Duration
+}
+
+// RetryTransport retries requests that failed with a connection error or a 500, 502, 503 or 504 from GitHub,
+// sleeping with jittered exponential backoff between attempts. Only idempotent methods are retried unless
+// RetryUnsafe is set. Rate limits are left to Client.WaitOnRateLimit.
+type RetryTransport struct {
+	// Base is the transport that sends the request, http.DefaultTransport when nil
+	Base http.RoundTripper
+	// MaxAttempts is the number of attempts including the first, DefaultMaxAttempts when zero
+	MaxAttempts int
+	// MinBackoff and MaxBackoff bound the wait between attempts, DefaultMinBackoff and DefaultMaxBackoff when zero
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RetryUnsafe also retries POST and PATCH, which may then take effect twice
+	RetryUnsafe bool
+	// OnRetry is called before each retry, e.g. to log or count them
+	OnRetry func(RetryEvent)
+}
+
+// RoundTrip sends the request, retrying transient failures
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxAttempts := t.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	retryable := (t.RetryUnsafe || idempotent(req.Method)) && canReplay(req)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := base(t.Base).RoundTrip(req)
+		if !retryable || attempt >= maxAttempts || !transient(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if t.OnRetry != nil {
+			t.OnRetry(RetryEvent{Request: req, Attempt: attempt, Response: resp, Err: err, Wait: wait})
+		}
+		if !sleep(req.Context(), wait) {
+			// the failed response is still the best answer when the caller gave up waiting
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if req, err = replay(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Client returns an http.Client using the transport
+func (t *RetryTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// backoff returns the wait after a failed attempt, honoring a Retry-After GitHub sent with a 503
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	min, max := t.MinBackoff, t.MaxBackoff
+	if min <= 0 {
+		min = DefaultMinBackoff
+	}
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+
+	if resp != nil {
+		if after := parseRetryAfter(resp.Header); after > 0 {
+			if after > max {
+				after = max
+			}
+			return after
+		}
+	}
+
+	d := min << uint(attempt-1)
+	if d > max || d <= 0 {
+		d = max
+	}
+	// equal jitter keeps at least half the backoff so retries never pile up right away
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// idempotent reports whether sending a request with the method twice has the same effect as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// transient reports whether a failed attempt is worth retrying
+func transient(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// sleep waits for d, returning false right away when the wait would outlast the ctx deadline, or when ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,117 @@
+package ghclient
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers the first failures requests with status and the rest with 200 OK
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method == http.MethodPut && string(body) != "payload" {
+			t.Errorf("attempt sent body %q", body)
+		}
+		if atomic.AddInt32(&requests, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestRetryTransportRetriesIdempotent(t *testing.T) {
+	srv, requests := flakyServer(t, 2, http.StatusBadGateway, nil)
+	var events []RetryEvent
+	tr := &RetryTransport{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, OnRetry: func(e RetryEvent) {
+		events = append(events, e)
+	}}
+
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, bytes.NewReader([]byte("payload")))
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || atomic.LoadInt32(requests) != 3 {
+		t.Errorf("status %d after %d requests", resp.StatusCode, *requests)
+	}
+	if len(events) != 2 || events[0].Attempt != 1 || events[1].Attempt != 2 {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestRetryTransportSkipsPost(t *testing.T) {
+	srv, requests := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+	tr := &RetryTransport{MinBackoff: time.Millisecond}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader([]byte("payload")))
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || atomic.LoadInt32(requests) != 1 {
+		t.Errorf("status %d after %d requests, want a single 503", resp.StatusCode, *requests)
+	}
+
+	tr.RetryUnsafe = true
+	atomic.StoreInt32(requests, 0)
+	req, _ = http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader([]byte("payload")))
+	if resp, err = tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if atomic.LoadInt32(requests) != 2 {
+		t.Errorf("RetryUnsafe sent %d requests, want 2", *requests)
+	}
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	srv, _ := flakyServer(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"1"}})
+	var wait time.Duration
+	tr := &RetryTransport{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Second, OnRetry: func(e RetryEvent) {
+		wait = e.Wait
+	}}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	start := time.Now()
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if wait != time.Second {
+		t.Errorf("waited %s, want the 1s of Retry-After", wait)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s", elapsed)
+	}
+}
+
+func TestRetryTransportKeepsClientErrors(t *testing.T) {
+	srv, requests := flakyServer(t, 1, http.StatusNotFound, nil)
+	tr := &RetryTransport{MinBackoff: time.Millisecond}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || atomic.LoadInt32(requests) != 1 {
+		t.Errorf("status %d after %d requests, want a single 404", resp.StatusCode, *requests)
+	}
+}
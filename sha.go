@@ -0,0 +1,74 @@
+package ghclient
+
+import (
+	"fmt"
+)
+
+// HeadSHA returns the commit a check should report on for the pull request
+func (e PullRequestEvent) HeadSHA() string {
+	return e.PullRequest.Head.Sha
+}
+
+// HeadSHA returns the commit a check should report on for the check run
+func (e CheckRunEvent) HeadSHA() string {
+	return e.CheckRun.HeadSha
+}
+
+// HeadSHA returns the commit a check should report on for the check suite
+func (e CheckSuiteEvent) HeadSHA() string {
+	return e.CheckSuite.HeadSha
+}
+
+// HeadSHA returns the commit a check should report on for the merge queue entry
+func (e MergeGroupEvent) HeadSHA() string {
+	return e.MergeGroup.HeadSha
+}
+
+// HeadSHA returns the commit the status was reported on
+func (e StatusEvent) HeadSHA() string {
+	return e.Sha
+}
+
+// HeadSHA returns the commit a check should report on from whichever trigger populated the event
+func (e Event) HeadSHA() string {
+	switch {
+	case e.MergeGroup.HeadSha != "":
+		return e.MergeGroup.HeadSha
+	case e.CheckRun.HeadSha != "":
+		return e.CheckRun.HeadSha
+	case e.CheckSuite.HeadSha != "":
+		return e.CheckSuite.HeadSha
+	case e.PullRequest.Head.Sha != "":
+		return e.PullRequest.Head.Sha
+	}
+	return e.Sha
+}
+
+// ReportSHA decodes the payload for the named GitHub event and returns the commit a check should report on
+func ReportSHA(githubEvent string, payload []byte) (string, error) {
+	var e interface{ HeadSHA() string }
+	switch githubEvent {
+	case CheckRunEventName:
+		e = &CheckRunEvent{}
+	case CheckSuiteEventName:
+		e = &CheckSuiteEvent{}
+	case MergeGroupEventName:
+		e = &MergeGroupEvent{}
+	case PullRequestEventName:
+		e = &PullRequestEvent{}
+	case StatusEventName:
+		e = &StatusEvent{}
+	default:
+		return "", fmt.Errorf("ghclient: event %q does not reference a commit", githubEvent)
+	}
+
+	if err := Decode(payload, e); err != nil {
+		return "", fmt.Errorf("ghclient: cannot decode %s payload: %s", githubEvent, err)
+	}
+
+	sha := e.HeadSHA()
+	if sha == "" {
+		return "", fmt.Errorf("ghclient: %s payload has no head SHA", githubEvent)
+	}
+	return sha, nil
+}
@@ -0,0 +1,112 @@
+package ghclient
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEventHeadSHA(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{"merge group wins", Event{
+			MergeGroup:  MergeGroup{HeadSha: "merge"},
+			CheckRun:    CheckRun{HeadSha: "run"},
+			CheckSuite:  CheckSuite{HeadSha: "suite"},
+			PullRequest: PullRequest{Head: Head{Sha: "head"}},
+			Sha:         "status",
+		}, "merge"},
+		{"check run before check suite", Event{
+			CheckRun:    CheckRun{HeadSha: "run"},
+			CheckSuite:  CheckSuite{HeadSha: "suite"},
+			PullRequest: PullRequest{Head: Head{Sha: "head"}},
+			Sha:         "status",
+		}, "run"},
+		{"check suite before pull request", Event{
+			CheckSuite:  CheckSuite{HeadSha: "suite"},
+			PullRequest: PullRequest{Head: Head{Sha: "head"}},
+			Sha:         "status",
+		}, "suite"},
+		{"pull request before status", Event{
+			PullRequest: PullRequest{Head: Head{Sha: "head"}},
+			Sha:         "status",
+		}, "head"},
+		{"status", Event{Sha: "status"}, "status"},
+		{"nothing", Event{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.HeadSHA(); got != tt.want {
+				t.Errorf("HeadSHA() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportSHA(t *testing.T) {
+	readExample := func(t *testing.T, name string) []byte {
+		t.Helper()
+		b, err := os.ReadFile("schemas/examples/api.github.com/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	tests := []struct {
+		githubEvent string
+		payload     func(t *testing.T) []byte
+		want        string
+	}{
+		{CheckRunEventName, func(t *testing.T) []byte { return readFixture(t, "check_run_created.json") }, "ec26c3e57ca3a959ca5aad62de7213c562f8c821"},
+		{CheckSuiteEventName, func(t *testing.T) []byte { return readFixture(t, "check_suite_completed.json") }, "ec26c3e57ca3a959ca5aad62de7213c562f8c821"},
+		{MergeGroupEventName, func(t *testing.T) []byte { return readExample(t, "merge_group/checks_requested.payload.json") }, "ec26c3e57ca3a959ca5aad62de7213c562f8c821"},
+		{PullRequestEventName, func(t *testing.T) []byte { return readFixture(t, "pull_request_synchronize.json") }, "b1f8a2d5e3c7a9f0d4e6b8c2a1f3e5d7c9b0a2e4"},
+		{StatusEventName, func(t *testing.T) []byte { return readExample(t, "status/event.payload.json") }, "ec26c3e57ca3a959ca5aad62de7213c562f8c821"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.githubEvent, func(t *testing.T) {
+			payload := tt.payload(t)
+			sha, err := ReportSHA(tt.githubEvent, payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sha != tt.want {
+				t.Errorf("ReportSHA = %q, want %q", sha, tt.want)
+			}
+
+			// the untyped event finds the same commit
+			var e Event
+			if err := Decode(payload, &e); err != nil {
+				t.Fatal(err)
+			}
+			if got := e.HeadSHA(); got != tt.want {
+				t.Errorf("Event.HeadSHA() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name        string
+		githubEvent string
+		payload     string
+		want        string
+	}{
+		{"unsupported event", PackageEventName, `{"action":"published"}`, `event "package" does not reference a commit`},
+		{"push is not a trigger", "push", `{"after":"ec26c3e57ca3a959ca5aad62de7213c562f8c821"}`, `event "push" does not reference a commit`},
+		{"missing SHA", CheckRunEventName, `{"action":"created","check_run":{"id":1}}`, "check_run payload has no head SHA"},
+		{"missing SHA in status", StatusEventName, `{"state":"success"}`, "status payload has no head SHA"},
+		{"invalid payload", PullRequestEventName, `{"pull_request":`, "cannot decode pull_request payload"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			sha, err := ReportSHA(tt.githubEvent, []byte(tt.payload))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReportSHA = %q, %v, want an error containing %q", sha, err, tt.want)
+			}
+		})
+	}
+}
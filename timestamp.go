@@ -0,0 +1,56 @@
+package ghclient
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time that decodes from both the Unix epoch seconds and RFC 3339 strings GitHub sends
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON accepts epoch seconds as a number or string, RFC 3339 strings, and null
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t.Time = time.Unix(secs, 0).UTC()
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("ghclient: cannot decode timestamp %s: %s", b, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON encodes the timestamp as an RFC 3339 string, or null for the zero Timestamp so a time GitHub
+// sent as null or omitted does not come back as 0001-01-01T00:00:00Z
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return t.Time.MarshalJSON()
+}
+
+// Equal reports whether t and u represent the same instant
+func (t Timestamp) Equal(u Timestamp) bool {
+	return t.Time.Equal(u.Time)
+}
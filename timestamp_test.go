@@ -0,0 +1,189 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want time.Time
+	}{
+		{"epoch number", `1525109898`, time.Unix(1525109898, 0)},
+		{"epoch string", `"1525109898"`, time.Unix(1525109898, 0)},
+		{"rfc3339 utc", `"2018-04-30T17:38:18Z"`, time.Unix(1525109898, 0)},
+		{"rfc3339 offset", `"2018-04-30T13:38:18-04:00"`, time.Unix(1525109898, 0)},
+		{"rfc3339 fraction", `"2018-04-30T19:38:18.250+02:00"`, time.Unix(1525109898, 250e6)},
+		{"null", `null`, time.Time{}},
+		{"empty string", `""`, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := Timestamp{time.Now()}
+			if err := json.Unmarshal([]byte(tt.json), &ts); err != nil {
+				t.Fatal(err)
+			}
+			if !ts.Time.Equal(tt.want) {
+				t.Errorf("got %v, want %v", ts.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampUnmarshalInvalid(t *testing.T) {
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Errorf("expected an error, got %v", ts)
+	}
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		ts   Timestamp
+		want string
+	}{
+		{"zero", Timestamp{}, `null`},
+		{"value", Timestamp{time.Unix(1525109898, 0).UTC()}, `"2018-04-30T17:38:18Z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("got %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDecodeFixtures(t *testing.T) {
+	t.Run("installation", func(t *testing.T) {
+		var e InstallationEvent
+		if err := Decode(readFixture(t, "installation_created.json"), &e, DisallowUnknownFields()); err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Unix(1525109898, 0); !e.Installation.CreatedAt.Time.Equal(want) {
+			t.Errorf("created_at = %v, want %v", e.Installation.CreatedAt, want)
+		}
+		if e.Installation.SuspendedAt != nil {
+			t.Errorf("suspended_at = %v, want nil", e.Installation.SuspendedAt)
+		}
+	})
+
+	t.Run("push repository", func(t *testing.T) {
+		var r Repository
+		if err := Decode(readFixture(t, "push_repository.json"), &r, DisallowUnknownFields()); err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Unix(1522349839, 0); !r.CreatedAt.Time.Equal(want) {
+			t.Errorf("created_at = %v, want %v", r.CreatedAt, want)
+		}
+		if want := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC); !r.UpdatedAt.Time.Equal(want) {
+			t.Errorf("updated_at = %v, want %v", r.UpdatedAt, want)
+		}
+		if r.PushedAt == nil || !r.PushedAt.Time.Equal(time.Unix(1680674828, 0)) {
+			t.Errorf("pushed_at = %v", r.PushedAt)
+		}
+	})
+
+	t.Run("check_run", func(t *testing.T) {
+		var e CheckRunEvent
+		if err := Decode(readFixture(t, "check_run_created.json"), &e, DisallowUnknownFields()); err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Date(2019, 5, 15, 13, 21, 12, 123e6, time.UTC); !e.CheckRun.StartedAt.Time.Equal(want) {
+			t.Errorf("started_at = %v, want %v", e.CheckRun.StartedAt, want)
+		}
+		if e.CheckRun.CompletedAt != nil {
+			t.Errorf("completed_at = %v, want nil", e.CheckRun.CompletedAt)
+		}
+		if want := time.Date(2017, 7, 8, 20, 18, 44, 0, time.UTC); !e.CheckRun.App.CreatedAt.Time.Equal(want) {
+			t.Errorf("app created_at = %v, want %v", e.CheckRun.App.CreatedAt, want)
+		}
+	})
+	t.Run("pull_request", func(t *testing.T) {
+		var e PullRequestEvent
+		if err := Decode(readFixture(t, "pull_request_opened.json"), &e, DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+			t.Fatal(err)
+		}
+		if e.Installation.ID != 2311213 || e.Organization.Login != "Octocoders" {
+			t.Errorf("installation = %d, organization = %q", e.Installation.ID, e.Organization.Login)
+		}
+		if e.PullRequest.Draft || e.PullRequest.AutoMerge != nil || e.PullRequest.ActiveLockReason != nil {
+			t.Errorf("draft = %v, auto_merge = %v, active_lock_reason = %v", e.PullRequest.Draft, e.PullRequest.AutoMerge, e.PullRequest.ActiveLockReason)
+		}
+		if !e.PullRequest.Head.Repo.AllowSquashMerge || e.PullRequest.Head.Repo.SquashMergeCommitTitle != "COMMIT_OR_PR_TITLE" {
+			t.Errorf("head repo merge settings = %+v", e.PullRequest.Head.Repo)
+		}
+
+		if err := Decode(readFixture(t, "pull_request_synchronize.json"), &e, DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+			t.Fatal(err)
+		}
+		if e.Before != "f95f852bd8fca8fcc58a9a2d6c842781e32a215e" || e.After != e.PullRequest.Head.Sha {
+			t.Errorf("before = %q, after = %q", e.Before, e.After)
+		}
+		if auto := e.PullRequest.AutoMerge; !e.PullRequest.Draft || auto == nil || auto.MergeMethod != MergeMethodSquash || auto.EnabledBy.Login != "Codertocat" {
+			t.Errorf("draft = %v, auto_merge = %+v", e.PullRequest.Draft, auto)
+		}
+	})
+
+	t.Run("check_suite", func(t *testing.T) {
+		var e CheckSuiteEvent
+		if err := Decode(readFixture(t, "check_suite_completed.json"), &e, DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+			t.Fatal(err)
+		}
+		if e.CheckSuite.Conclusion != CheckConclusionSuccess || e.CheckSuite.LatestCheckRunsCount != 1 {
+			t.Errorf("conclusion = %q, latest_check_runs_count = %d", e.CheckSuite.Conclusion, e.CheckSuite.LatestCheckRunsCount)
+		}
+		if e.CheckSuite.HeadCommit.ID != e.CheckSuite.HeadSha || e.CheckSuite.HeadCommit.Author.Name != "Codertocat" {
+			t.Errorf("head_commit = %+v", e.CheckSuite.HeadCommit)
+		}
+	})
+
+	t.Run("package", func(t *testing.T) {
+		var e PackageEvent
+		if err := Decode(readFixture(t, "package_published.json"), &e, DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+			t.Fatal(err)
+		}
+		if e.Package.PackageType != PackageTypeContainer || e.Package.PackageVersion.ContainerMetadata.Tag.Name != "v1.2.0" {
+			t.Errorf("package = %q, tag = %q", e.Package.PackageType, e.Package.PackageVersion.ContainerMetadata.Tag.Name)
+		}
+	})
+
+	t.Run("registry_package", func(t *testing.T) {
+		var e RegistryPackageEvent
+		if err := Decode(readFixture(t, "registry_package_published.json"), &e, DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+			t.Fatal(err)
+		}
+		if e.RegistryPackage.Registry.URL != "https://ghcr.io/octocoders" {
+			t.Errorf("registry = %q", e.RegistryPackage.Registry.URL)
+		}
+	})
+
+	t.Run("repository_dispatch", func(t *testing.T) {
+		var e RepositoryDispatchEvent[map[string]interface{}]
+		if err := Decode(readFixture(t, "repository_dispatch.json"), &e, DisallowUnknownFields()); err != nil {
+			t.Fatal(err)
+		}
+		if e.Branch != "master" || e.ClientPayload["environment"] != "staging" {
+			t.Errorf("branch = %q, client_payload = %v", e.Branch, e.ClientPayload)
+		}
+	})
+}
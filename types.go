@@ -1,311 +1,669 @@
 package ghclient
 
-import (
-	"time"
-)
-
 // Event is triggered by the GitHub app.
 type Event struct {
+	Payload
+
+	// Action is not checked by DisallowUnknownActions unless the event is named with ForEvent
 	Action       string       `json:"action"`
 	CheckRun     CheckRun     `json:"check_run"`
+	CheckSuite   CheckSuite   `json:"check_suite"`
 	Installation Installation `json:"installation"`
+	MergeGroup   MergeGroup   `json:"merge_group"`
 	Number       int          `json:"number"`
 	Organization Organization `json:"organization"`
 	PullRequest  PullRequest  `json:"pull_request"`
 	Repository   Repository   `json:"repository"`
 	Repositories []Repository `json:"repositories"`
 	Sender       Sender       `json:"sender"`
+	Sha          string       `json:"sha"`
 }
 
 // InstallationEvent is triggered when a GitHub app is either installed or removed.
 type InstallationEvent struct {
-	Action       string       `json:"action"`
-	Installation Installation `json:"installation"`
-	Repositories []Repository `json:"repositories"`
-	Sender       Sender       `json:"sender"`
+	Payload
+
+	Action       InstallationAction `json:"action"`
+	Installation Installation       `json:"installation"`
+	Repositories []Repository       `json:"repositories"`
+	Requester    *User              `json:"requester"`
+	Sender       Sender             `json:"sender"`
 }
 
 // CheckRunEvent is triggered when a Check Run is created, rerequested, completed or has a requested action.
 type CheckRunEvent struct {
-	Action       string       `json:"action"`
-	CheckRun     CheckRun     `json:"check_run"`
-	Repository   Repository   `json:"repository"`
-	Organization Organization `json:"organization"`
-	Sender       Sender       `json:"sender"`
-	Installation Installation `json:"installation"`
+	Payload
+
+	Action          CheckRunAction `json:"action"`
+	CheckRun        CheckRun       `json:"check_run"`
+	RequestedAction CheckAction    `json:"requested_action"`
+	Repository      Repository     `json:"repository"`
+	Organization    Organization   `json:"organization"`
+	Sender          Sender         `json:"sender"`
+	Installation    Installation   `json:"installation"`
 }
 
 // PullRequestEvent is triggered when a Pull Request is assigned, unassigned, labeled, unlabeled, opened, edited, closed, reopened, synchronized
 type PullRequestEvent struct {
-	Action      string      `json:"action"`
-	Number      int         `json:"number"`
-	PullRequest PullRequest `json:"pull_request"`
-	Repository  Repository  `json:"repository"`
-	Sender      Sender      `json:"sender"`
+	Payload
+
+	Action       PullRequestAction `json:"action"`
+	Number       int               `json:"number"`
+	PullRequest  PullRequest       `json:"pull_request"`
+	Before       string            `json:"before"`
+	After        string            `json:"after"`
+	Repository   Repository        `json:"repository"`
+	Organization Organization      `json:"organization"`
+	Sender       Sender            `json:"sender"`
+	Installation Installation      `json:"installation"`
+}
+
+// CheckSuiteEvent is triggered when a Check Suite is requested, rerequested or completed.
+type CheckSuiteEvent struct {
+	Payload
+
+	Action       CheckSuiteAction `json:"action"`
+	CheckSuite   CheckSuite       `json:"check_suite"`
+	Repository   Repository       `json:"repository"`
+	Organization Organization     `json:"organization"`
+	Sender       Sender           `json:"sender"`
+	Installation Installation     `json:"installation"`
+}
+
+// PackageEvent is triggered when a package is published or updated in GitHub Packages.
+type PackageEvent struct {
+	Payload
+
+	Action       PackageAction `json:"action"`
+	Package      Package       `json:"package"`
+	Repository   Repository    `json:"repository"`
+	Organization Organization  `json:"organization"`
+	Sender       Sender        `json:"sender"`
+	Installation Installation  `json:"installation"`
+}
+
+// RegistryPackageEvent is triggered when a package is published or updated in a GitHub Packages registry.
+type RegistryPackageEvent struct {
+	Payload
+
+	Action          PackageAction `json:"action"`
+	RegistryPackage Package       `json:"registry_package"`
+	Repository      Repository    `json:"repository"`
+	Organization    Organization  `json:"organization"`
+	Sender          Sender        `json:"sender"`
+	Installation    Installation  `json:"installation"`
 }
 
 // Output used provide information back to the requester
 type Output struct {
-	Title            string `json:"title"`
-	Summary          string `json:"summary"`
-	Text             string `json:"text"`
-	AnnotationsCount int    `json:"annotations_count"`
-	AnnotationsURL   string `json:"annotations_url"`
+	Title            string       `json:"title"`
+	Summary          string       `json:"summary"`
+	Text             string       `json:"text"`
+	AnnotationsCount int          `json:"annotations_count"`
+	AnnotationsURL   string       `json:"annotations_url"`
+	Annotations      []Annotation `json:"annotations"`
+}
+
+// Annotation flags a range of lines in a file with a message shown on the check run and the pull request diff
+type Annotation struct {
+	Path            string          `json:"path"`
+	StartLine       int             `json:"start_line"`
+	EndLine         int             `json:"end_line"`
+	StartColumn     *int            `json:"start_column,omitempty"`
+	EndColumn       *int            `json:"end_column,omitempty"`
+	AnnotationLevel AnnotationLevel `json:"annotation_level"`
+	Message         string          `json:"message"`
+	Title           string          `json:"title,omitempty"`
+	RawDetails      string          `json:"raw_details,omitempty"`
+	BlobHref        string          `json:"blob_href,omitempty"`
 }
 
 // CheckRun is used to run different types of checks (quality, security, dependency) against a repository.
 type CheckRun struct {
-	ID           int           `json:"id"`
-	HeadSha      string        `json:"head_sha"`
-	ExternalID   string        `json:"external_id"`
-	URL          string        `json:"url"`
-	HTMLURL      string        `json:"html_url"`
-	Status       string        `json:"status"`
-	Conclusion   string        `json:"conclusion"`
-	StartedAt    time.Time     `json:"started_at"`
-	CompletedAt  time.Time     `json:"completed_at"`
-	Output       Output        `json:"output"`
-	Name         string        `json:"name"`
-	CheckSuite   CheckSuite    `json:"check_suite"`
-	App          App           `json:"app"`
-	PullRequests []interface{} `json:"pull_requests"`
+	ID           int             `json:"id"`
+	NodeID       string          `json:"node_id"`
+	HeadSha      string          `json:"head_sha"`
+	ExternalID   string          `json:"external_id"`
+	URL          string          `json:"url"`
+	HTMLURL      string          `json:"html_url"`
+	DetailsURL   string          `json:"details_url"`
+	Status       CheckStatus     `json:"status"`
+	Conclusion   CheckConclusion `json:"conclusion"`
+	StartedAt    Timestamp       `json:"started_at"`
+	CompletedAt  *Timestamp      `json:"completed_at"`
+	Output       Output          `json:"output"`
+	Name         string          `json:"name"`
+	CheckSuite   CheckSuite      `json:"check_suite"`
+	App          App             `json:"app"`
+	PullRequests []interface{}   `json:"pull_requests"`
+	Actions      []CheckAction   `json:"actions"`
+}
+
+// CheckAction is a button shown on a check run, clicking it sends a requested_action check_run event
+type CheckAction struct {
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Identifier  string `json:"identifier"`
 }
 
 // CheckSuite contains one or more Check Runs
 type CheckSuite struct {
-	ID           int           `json:"id"`
-	HeadBranch   string        `json:"head_branch"`
-	HeadSha      string        `json:"head_sha"`
-	Status       string        `json:"status"`
-	Conclusion   string        `json:"conclusion"`
-	URL          string        `json:"url"`
-	Before       string        `json:"before"`
-	After        string        `json:"after"`
-	PullRequests []interface{} `json:"pull_requests"`
-	App          App           `json:"app"`
-	CreatedAt    time.Time     `json:"created_at"`
-	UpdatedAt    time.Time     `json:"updated_at"`
+	ID           int             `json:"id"`
+	NodeID       string          `json:"node_id"`
+	HeadBranch   string          `json:"head_branch"`
+	HeadSha      string          `json:"head_sha"`
+	Status       CheckStatus     `json:"status"`
+	Conclusion   CheckConclusion `json:"conclusion"`
+	URL          string          `json:"url"`
+	Before       string          `json:"before"`
+	After        string          `json:"after"`
+	PullRequests []interface{}   `json:"pull_requests"`
+	App          App             `json:"app"`
+	CreatedAt    Timestamp       `json:"created_at"`
+	UpdatedAt    Timestamp       `json:"updated_at"`
+	HeadCommit   HeadCommit      `json:"head_commit"`
+	CheckRunsURL string          `json:"check_runs_url"`
+	// LatestCheckRunsCount is the number of check runs in the suite, not counting rerequested ones
+	LatestCheckRunsCount int  `json:"latest_check_runs_count"`
+	Rerequestable        bool `json:"rerequestable"`
+	RunsRerequestable    bool `json:"runs_rerequestable"`
+}
+
+// RepositoryCommit is a commit as the REST API returns it, e.g. from the commits of a pull request. Stats and Files
+// are only returned when a single commit is requested.
+type RepositoryCommit struct {
+	Sha         string `json:"sha"`
+	NodeID      string `json:"node_id"`
+	Commit      Commit `json:"commit"`
+	URL         string `json:"url"`
+	HTMLURL     string `json:"html_url"`
+	CommentsURL string `json:"comments_url"`
+	// Author and Committer are nil when the commit emails do not belong to a GitHub account
+	Author    *User          `json:"author"`
+	Committer *User          `json:"committer"`
+	Parents   []CommitParent `json:"parents"`
+	Stats     *CommitStats   `json:"stats,omitempty"`
+	Files     []CommitFile   `json:"files,omitempty"`
+}
+
+// Commit is the git data of a commit
+type Commit struct {
+	URL          string                 `json:"url"`
+	Author       CommitAuthor           `json:"author"`
+	Committer    CommitAuthor           `json:"committer"`
+	Message      string                 `json:"message"`
+	CommentCount int                    `json:"comment_count"`
+	Tree         CommitTree             `json:"tree"`
+	Verification *SignatureVerification `json:"verification"`
+}
+
+// CommitAuthor is the git author or committer of a commit, who may not have a GitHub account
+type CommitAuthor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  Timestamp `json:"date"`
+}
+
+// CommitTree references the tree of a commit
+type CommitTree struct {
+	Sha string `json:"sha"`
+	URL string `json:"url"`
+}
+
+// CommitParent references a parent of a commit
+type CommitParent struct {
+	Sha     string `json:"sha"`
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CommitStats counts the lines a commit changes
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// SignatureVerification says whether GitHub could verify the signature of a commit
+type SignatureVerification struct {
+	Verified bool `json:"verified"`
+	// Reason is e.g. valid, unsigned or unknown_key
+	Reason     string     `json:"reason"`
+	Signature  *string    `json:"signature"`
+	Payload    *string    `json:"payload"`
+	VerifiedAt *Timestamp `json:"verified_at"`
+}
+
+// Package contains details about a package published to GitHub Packages
+type Package struct {
+	ID             int            `json:"id"`
+	Name           string         `json:"name"`
+	Namespace      string         `json:"namespace"`
+	Description    string         `json:"description"`
+	Ecosystem      string         `json:"ecosystem"`
+	PackageType    PackageType    `json:"package_type"`
+	HTMLURL        string         `json:"html_url"`
+	CreatedAt      Timestamp      `json:"created_at"`
+	UpdatedAt      Timestamp      `json:"updated_at"`
+	Owner          User           `json:"owner"`
+	PackageVersion PackageVersion `json:"package_version"`
+	Registry       Registry       `json:"registry"`
+}
+
+// PackageVersion contains details about a single published version of a package
+type PackageVersion struct {
+	ID                  int                    `json:"id"`
+	Version             string                 `json:"version"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	Summary             string                 `json:"summary"`
+	Body                interface{}            `json:"body"`
+	BodyHTML            string                 `json:"body_html"`
+	HTMLURL             string                 `json:"html_url"`
+	TagName             string                 `json:"tag_name"`
+	TargetCommitish     string                 `json:"target_commitish"`
+	TargetOid           string                 `json:"target_oid"`
+	Draft               bool                   `json:"draft"`
+	Prerelease          bool                   `json:"prerelease"`
+	CreatedAt           Timestamp              `json:"created_at"`
+	UpdatedAt           Timestamp              `json:"updated_at"`
+	Metadata            []interface{}          `json:"metadata"`
+	ContainerMetadata   ContainerMetadata      `json:"container_metadata"`
+	NpmMetadata         map[string]interface{} `json:"npm_metadata"`
+	PackageFiles        []PackageFile          `json:"package_files"`
+	PackageURL          string                 `json:"package_url"`
+	Author              User                   `json:"author"`
+	InstallationCommand string                 `json:"installation_command"`
+}
+
+// ContainerMetadata contains the image details of a container package version
+type ContainerMetadata struct {
+	Tag      ContainerTag           `json:"tag"`
+	Labels   map[string]interface{} `json:"labels"`
+	Manifest ContainerManifest      `json:"manifest"`
+}
+
+// ContainerTag is the tag a container package version was pushed with
+type ContainerTag struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest"`
+}
+
+// ContainerManifest describes the image manifest of a container package version
+type ContainerManifest struct {
+	Digest    string                   `json:"digest"`
+	MediaType string                   `json:"media_type"`
+	URI       string                   `json:"uri"`
+	Size      int                      `json:"size"`
+	Config    map[string]interface{}   `json:"config"`
+	Layers    []map[string]interface{} `json:"layers"`
+}
+
+// PackageFile is a file uploaded as part of a package version
+type PackageFile struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	DownloadURL string    `json:"download_url"`
+	ContentType string    `json:"content_type"`
+	State       string    `json:"state"`
+	Size        int       `json:"size"`
+	SHA256      string    `json:"sha256"`
+	SHA1        string    `json:"sha1"`
+	MD5         string    `json:"md5"`
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+}
+
+// Registry contains details about the registry hosting a package
+type Registry struct {
+	AboutURL string `json:"about_url"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	URL      string `json:"url"`
+	Vendor   string `json:"vendor"`
+}
+
+// IssueComment is a comment in the conversation of an issue or pull request
+type IssueComment struct {
+	URL               string    `json:"url"`
+	HTMLURL           string    `json:"html_url"`
+	IssueURL          string    `json:"issue_url"`
+	ID                int       `json:"id"`
+	NodeID            string    `json:"node_id"`
+	User              User      `json:"user"`
+	CreatedAt         Timestamp `json:"created_at"`
+	UpdatedAt         Timestamp `json:"updated_at"`
+	AuthorAssociation string    `json:"author_association"`
+	Body              string    `json:"body"`
 }
 
 // App contains information about the GitHub Application
 type App struct {
 	ID          int         `json:"id"`
+	ClientID    string      `json:"client_id"`
+	Slug        string      `json:"slug"`
 	NodeID      string      `json:"node_id"`
 	Owner       Owner       `json:"owner"`
 	Name        string      `json:"name"`
-	Description interface{} `json:"description"`
+	Description *string     `json:"description"`
 	ExternalURL string      `json:"external_url"`
 	HTMLURL     string      `json:"html_url"`
-	CreatedAt   string      `json:"created_at"`
-	UpdatedAt   string      `json:"updated_at"`
+	CreatedAt   Timestamp   `json:"created_at"`
+	UpdatedAt   Timestamp   `json:"updated_at"`
+	Permissions Permissions `json:"permissions"`
+	Events      []string    `json:"events"`
 }
 
 // Repository contains information about the repository, can be used to reference a specific commit (last, current head, etc.)
 type Repository struct {
-	ID               int         `json:"id"`
-	NodeID           string      `json:"node_id"`
-	Name             string      `json:"name"`
-	FullName         string      `json:"full_name"`
-	Owner            Owner       `json:"owner"`
-	Private          bool        `json:"private"`
-	HTMLURL          string      `json:"html_url"`
-	Description      interface{} `json:"description"`
-	Fork             bool        `json:"fork"`
-	URL              string      `json:"url"`
-	ForksURL         string      `json:"forks_url"`
-	KeysURL          string      `json:"keys_url"`
-	CollaboratorsURL string      `json:"collaborators_url"`
-	TeamsURL         string      `json:"teams_url"`
-	HooksURL         string      `json:"hooks_url"`
-	IssueEventsURL   string      `json:"issue_events_url"`
-	EventsURL        string      `json:"events_url"`
-	AssigneesURL     string      `json:"assignees_url"`
-	BranchesURL      string      `json:"branches_url"`
-	TagsURL          string      `json:"tags_url"`
-	BlobsURL         string      `json:"blobs_url"`
-	GitTagsURL       string      `json:"git_tags_url"`
-	GitRefsURL       string      `json:"git_refs_url"`
-	TreesURL         string      `json:"trees_url"`
-	StatusesURL      string      `json:"statuses_url"`
-	LanguagesURL     string      `json:"languages_url"`
-	StargazersURL    string      `json:"stargazers_url"`
-	ContributorsURL  string      `json:"contributors_url"`
-	SubscribersURL   string      `json:"subscribers_url"`
-	SubscriptionURL  string      `json:"subscription_url"`
-	CommitsURL       string      `json:"commits_url"`
-	GitCommitsURL    string      `json:"git_commits_url"`
-	CommentsURL      string      `json:"comments_url"`
-	IssueCommentURL  string      `json:"issue_comment_url"`
-	ContentsURL      string      `json:"contents_url"`
-	CompareURL       string      `json:"compare_url"`
-	MergesURL        string      `json:"merges_url"`
-	ArchiveURL       string      `json:"archive_url"`
-	DownloadsURL     string      `json:"downloads_url"`
-	IssuesURL        string      `json:"issues_url"`
-	PullsURL         string      `json:"pulls_url"`
-	MilestonesURL    string      `json:"milestones_url"`
-	NotificationsURL string      `json:"notifications_url"`
-	LabelsURL        string      `json:"labels_url"`
-	ReleasesURL      string      `json:"releases_url"`
-	DeploymentsURL   string      `json:"deployments_url"`
-	CreatedAt        time.Time   `json:"created_at"`
-	UpdatedAt        time.Time   `json:"updated_at"`
-	PushedAt         time.Time   `json:"pushed_at"`
-	GitURL           string      `json:"git_url"`
-	SSHURL           string      `json:"ssh_url"`
-	CloneURL         string      `json:"clone_url"`
-	SvnURL           string      `json:"svn_url"`
-	Homepage         interface{} `json:"homepage"`
-	Size             int         `json:"size"`
-	StargazersCount  int         `json:"stargazers_count"`
-	WatchersCount    int         `json:"watchers_count"`
-	Language         interface{} `json:"language"`
-	HasIssues        bool        `json:"has_issues"`
-	HasProjects      bool        `json:"has_projects"`
-	HasDownloads     bool        `json:"has_downloads"`
-	HasWiki          bool        `json:"has_wiki"`
-	HasPages         bool        `json:"has_pages"`
-	ForksCount       int         `json:"forks_count"`
-	MirrorURL        interface{} `json:"mirror_url"`
-	Archived         bool        `json:"archived"`
-	OpenIssuesCount  int         `json:"open_issues_count"`
-	License          interface{} `json:"license"`
-	Forks            int         `json:"forks"`
-	OpenIssues       int         `json:"open_issues"`
-	Watchers         int         `json:"watchers"`
-	DefaultBranch    string      `json:"default_branch"`
+	ID               int        `json:"id"`
+	NodeID           string     `json:"node_id"`
+	Name             string     `json:"name"`
+	FullName         string     `json:"full_name"`
+	Owner            Owner      `json:"owner"`
+	Private          bool       `json:"private"`
+	HTMLURL          string     `json:"html_url"`
+	Description      *string    `json:"description"`
+	Fork             bool       `json:"fork"`
+	URL              string     `json:"url"`
+	ForksURL         string     `json:"forks_url"`
+	KeysURL          string     `json:"keys_url"`
+	CollaboratorsURL string     `json:"collaborators_url"`
+	TeamsURL         string     `json:"teams_url"`
+	HooksURL         string     `json:"hooks_url"`
+	IssueEventsURL   string     `json:"issue_events_url"`
+	EventsURL        string     `json:"events_url"`
+	AssigneesURL     string     `json:"assignees_url"`
+	BranchesURL      string     `json:"branches_url"`
+	TagsURL          string     `json:"tags_url"`
+	BlobsURL         string     `json:"blobs_url"`
+	GitTagsURL       string     `json:"git_tags_url"`
+	GitRefsURL       string     `json:"git_refs_url"`
+	TreesURL         string     `json:"trees_url"`
+	StatusesURL      string     `json:"statuses_url"`
+	LanguagesURL     string     `json:"languages_url"`
+	StargazersURL    string     `json:"stargazers_url"`
+	ContributorsURL  string     `json:"contributors_url"`
+	SubscribersURL   string     `json:"subscribers_url"`
+	SubscriptionURL  string     `json:"subscription_url"`
+	CommitsURL       string     `json:"commits_url"`
+	GitCommitsURL    string     `json:"git_commits_url"`
+	CommentsURL      string     `json:"comments_url"`
+	IssueCommentURL  string     `json:"issue_comment_url"`
+	ContentsURL      string     `json:"contents_url"`
+	CompareURL       string     `json:"compare_url"`
+	MergesURL        string     `json:"merges_url"`
+	ArchiveURL       string     `json:"archive_url"`
+	DownloadsURL     string     `json:"downloads_url"`
+	IssuesURL        string     `json:"issues_url"`
+	PullsURL         string     `json:"pulls_url"`
+	MilestonesURL    string     `json:"milestones_url"`
+	NotificationsURL string     `json:"notifications_url"`
+	LabelsURL        string     `json:"labels_url"`
+	ReleasesURL      string     `json:"releases_url"`
+	DeploymentsURL   string     `json:"deployments_url"`
+	CreatedAt        Timestamp  `json:"created_at"`
+	UpdatedAt        Timestamp  `json:"updated_at"`
+	PushedAt         *Timestamp `json:"pushed_at"`
+	GitURL           string     `json:"git_url"`
+	SSHURL           string     `json:"ssh_url"`
+	CloneURL         string     `json:"clone_url"`
+	SvnURL           string     `json:"svn_url"`
+	Homepage         *string    `json:"homepage"`
+	Size             int        `json:"size"`
+	StargazersCount  int        `json:"stargazers_count"`
+	WatchersCount    int        `json:"watchers_count"`
+	Language         *string    `json:"language"`
+	HasIssues        bool       `json:"has_issues"`
+	HasProjects      bool       `json:"has_projects"`
+	HasDownloads     bool       `json:"has_downloads"`
+	HasWiki          bool       `json:"has_wiki"`
+	HasPages         bool       `json:"has_pages"`
+	HasDiscussions   bool       `json:"has_discussions"`
+	ForksCount       int        `json:"forks_count"`
+	MirrorURL        *string    `json:"mirror_url"`
+	Archived         bool       `json:"archived"`
+	Disabled         bool       `json:"disabled"`
+	OpenIssuesCount  int        `json:"open_issues_count"`
+	License          *License   `json:"license"`
+	Forks            int        `json:"forks"`
+	OpenIssues       int        `json:"open_issues"`
+	Watchers         int        `json:"watchers"`
+	DefaultBranch    string     `json:"default_branch"`
+	// MasterBranch repeats DefaultBranch in push payloads
+	MasterBranch string `json:"master_branch"`
+	// Stargazers repeats StargazersCount in push payloads
+	Stargazers               int      `json:"stargazers"`
+	AllowForking             bool     `json:"allow_forking"`
+	IsTemplate               bool     `json:"is_template"`
+	WebCommitSignoffRequired bool     `json:"web_commit_signoff_required"`
+	Topics                   []string `json:"topics"`
+	Visibility               string   `json:"visibility"`
+	// CustomProperties maps the organization's custom property names to the values set on the repository
+	CustomProperties map[string]interface{} `json:"custom_properties"`
+	// the merge settings are only sent with the head and base repositories of a pull request
+	AllowSquashMerge          bool   `json:"allow_squash_merge"`
+	AllowMergeCommit          bool   `json:"allow_merge_commit"`
+	AllowRebaseMerge          bool   `json:"allow_rebase_merge"`
+	AllowAutoMerge            bool   `json:"allow_auto_merge"`
+	AllowUpdateBranch         bool   `json:"allow_update_branch"`
+	DeleteBranchOnMerge       bool   `json:"delete_branch_on_merge"`
+	UseSquashPRTitleAsDefault bool   `json:"use_squash_pr_title_as_default"`
+	SquashMergeCommitTitle    string `json:"squash_merge_commit_title"`
+	SquashMergeCommitMessage  string `json:"squash_merge_commit_message"`
+	MergeCommitTitle          string `json:"merge_commit_title"`
+	MergeCommitMessage        string `json:"merge_commit_message"`
 }
 
-// Owner contains details about the Repository or App owner
-type Owner struct {
-	Login             string `json:"login"`
-	ID                int    `json:"id"`
-	NodeID            string `json:"node_id"`
-	AvatarURL         string `json:"avatar_url"`
-	GravatarID        string `json:"gravatar_id"`
-	URL               string `json:"url"`
-	HTMLURL           string `json:"html_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReposURL          string `json:"repos_url"`
-	EventsURL         string `json:"events_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	Type              string `json:"type"`
-	SiteAdmin         bool   `json:"site_admin"`
+// License contains details about the license detected for a repository
+type License struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SpdxID string `json:"spdx_id"`
+	URL    string `json:"url"`
+	NodeID string `json:"node_id"`
 }
 
+// Owner contains details about the Repository or App owner
+type Owner = Account
+
 // Organization contains details about the GitHub Organization
-type Organization struct {
-	Login             string `json:"login"`
-	ID                int    `json:"id"`
-	AvatarURL         string `json:"avatar_url"`
-	GravatarID        string `json:"gravatar_id"`
-	URL               string `json:"url"`
-	HTMLURL           string `json:"html_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReposURL          string `json:"repos_url"`
-	EventsURL         string `json:"events_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	Type              string `json:"type"`
-	SiteAdmin         bool   `json:"site_admin"`
-}
+type Organization = Account
 
 // Installation contains details about the Installation
 type Installation struct {
-	ID                  int         `json:"id"`
-	Account             Account     `json:"account"`
-	RepositorySelection string      `json:"repository_selection"`
-	AccessTokensURL     string      `json:"access_tokens_url"`
-	RepositoriesURL     string      `json:"repositories_url"`
-	HTMLURL             string      `json:"html_url"`
-	AppID               int         `json:"app_id"`
-	TargetID            int         `json:"target_id"`
-	TargetType          string      `json:"target_type"`
-	Permissions         Permissions `json:"permissions"`
-	Events              []string    `json:"events"`
-	CreatedAt           int         `json:"created_at"`
-	UpdatedAt           int         `json:"updated_at"`
-	SingleFileName      string      `json:"single_file_name"`
+	ID                     int                 `json:"id"`
+	Account                Account             `json:"account"`
+	RepositorySelection    RepositorySelection `json:"repository_selection"`
+	AccessTokensURL        string              `json:"access_tokens_url"`
+	RepositoriesURL        string              `json:"repositories_url"`
+	HTMLURL                string              `json:"html_url"`
+	AppID                  int                 `json:"app_id"`
+	TargetID               int                 `json:"target_id"`
+	TargetType             string              `json:"target_type"`
+	Permissions            Permissions         `json:"permissions"`
+	Events                 []string            `json:"events"`
+	CreatedAt              Timestamp           `json:"created_at"`
+	UpdatedAt              Timestamp           `json:"updated_at"`
+	SingleFileName         string              `json:"single_file_name"`
+	HasMultipleSingleFiles bool                `json:"has_multiple_single_files"`
+	SingleFilePaths        []string            `json:"single_file_paths"`
+	SuspendedBy            *User               `json:"suspended_by"`
+	SuspendedAt            *Timestamp          `json:"suspended_at"`
+	AppSlug                string              `json:"app_slug"`
+	NodeID                 string              `json:"node_id"`
 }
 
 // Sender provides details about the person or service triggering the event
-type Sender struct {
-	Login             string `json:"login"`
-	ID                int    `json:"id"`
-	NodeID            string `json:"node_id"`
-	AvatarURL         string `json:"avatar_url"`
-	GravatarID        string `json:"gravatar_id"`
-	URL               string `json:"url"`
-	HTMLURL           string `json:"html_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReposURL          string `json:"repos_url"`
-	EventsURL         string `json:"events_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	Type              string `json:"type"`
-	SiteAdmin         bool   `json:"site_admin"`
-}
+type Sender = Account
 
 // PullRequest provides details about the pull request
 type PullRequest struct {
-	URL                 string        `json:"url"`
-	ID                  int           `json:"id"`
-	NodeID              string        `json:"node_id"`
-	HTMLURL             string        `json:"html_url"`
-	DiffURL             string        `json:"diff_url"`
-	PatchURL            string        `json:"patch_url"`
-	IssueURL            string        `json:"issue_url"`
-	Number              int           `json:"number"`
-	State               string        `json:"state"`
-	Locked              bool          `json:"locked"`
-	Title               string        `json:"title"`
-	User                User          `json:"user"`
-	Body                string        `json:"body"`
-	CreatedAt           time.Time     `json:"created_at"`
-	UpdatedAt           time.Time     `json:"updated_at"`
-	ClosedAt            time.Time     `json:"closed_at"`
-	MergedAt            interface{}   `json:"merged_at"`
-	MergeCommitSha      string        `json:"merge_commit_sha"`
-	Assignee            interface{}   `json:"assignee"`
-	Assignees           []interface{} `json:"assignees"`
-	RequestedReviewers  []interface{} `json:"requested_reviewers"`
-	RequestedTeams      []interface{} `json:"requested_teams"`
-	Labels              []interface{} `json:"labels"`
-	Milestone           interface{}   `json:"milestone"`
-	CommitsURL          string        `json:"commits_url"`
-	ReviewCommentsURL   string        `json:"review_comments_url"`
-	ReviewCommentURL    string        `json:"review_comment_url"`
-	CommentsURL         string        `json:"comments_url"`
-	StatusesURL         string        `json:"statuses_url"`
-	Head                Head          `json:"head"`
-	Base                Base          `json:"base"`
-	Links               Links         `json:"_links"`
-	AuthorAssociation   string        `json:"author_association"`
-	Merged              bool          `json:"merged"`
-	Mergeable           bool          `json:"mergeable"`
-	Rebaseable          bool          `json:"rebaseable"`
-	MergeableState      string        `json:"mergeable_state"`
-	MergedBy            interface{}   `json:"merged_by"`
-	Comments            int           `json:"comments"`
-	ReviewComments      int           `json:"review_comments"`
-	MaintainerCanModify bool          `json:"maintainer_can_modify"`
-	Commits             int           `json:"commits"`
-	Additions           int           `json:"additions"`
-	Deletions           int           `json:"deletions"`
-	ChangedFiles        int           `json:"changed_files"`
+	URL                 string         `json:"url"`
+	ID                  int            `json:"id"`
+	NodeID              string         `json:"node_id"`
+	HTMLURL             string         `json:"html_url"`
+	DiffURL             string         `json:"diff_url"`
+	PatchURL            string         `json:"patch_url"`
+	IssueURL            string         `json:"issue_url"`
+	Number              int            `json:"number"`
+	State               string         `json:"state"`
+	Locked              bool           `json:"locked"`
+	ActiveLockReason    *string        `json:"active_lock_reason"`
+	Title               string         `json:"title"`
+	User                User           `json:"user"`
+	Body                *string        `json:"body"`
+	CreatedAt           Timestamp      `json:"created_at"`
+	UpdatedAt           Timestamp      `json:"updated_at"`
+	ClosedAt            *Timestamp     `json:"closed_at"`
+	MergedAt            *Timestamp     `json:"merged_at"`
+	MergeCommitSha      string         `json:"merge_commit_sha"`
+	Assignee            *User          `json:"assignee"`
+	Assignees           []User         `json:"assignees"`
+	RequestedReviewers  []User         `json:"requested_reviewers"`
+	RequestedTeams      []Team         `json:"requested_teams"`
+	Labels              []Label        `json:"labels"`
+	Milestone           *Milestone     `json:"milestone"`
+	Draft               bool           `json:"draft"`
+	AutoMerge           *AutoMerge     `json:"auto_merge"`
+	CommitsURL          string         `json:"commits_url"`
+	ReviewCommentsURL   string         `json:"review_comments_url"`
+	ReviewCommentURL    string         `json:"review_comment_url"`
+	CommentsURL         string         `json:"comments_url"`
+	StatusesURL         string         `json:"statuses_url"`
+	Head                Head           `json:"head"`
+	Base                Base           `json:"base"`
+	Links               Links          `json:"_links"`
+	AuthorAssociation   string         `json:"author_association"`
+	Merged              bool           `json:"merged"`
+	Mergeable           Nullable[bool] `json:"mergeable"`
+	Rebaseable          Nullable[bool] `json:"rebaseable"`
+	MergeableState      string         `json:"mergeable_state"`
+	MergedBy            *User          `json:"merged_by"`
+	Comments            int            `json:"comments"`
+	ReviewComments      int            `json:"review_comments"`
+	MaintainerCanModify bool           `json:"maintainer_can_modify"`
+	Commits             int            `json:"commits"`
+	Additions           int            `json:"additions"`
+	Deletions           int            `json:"deletions"`
+	ChangedFiles        int            `json:"changed_files"`
+}
+
+// AutoMerge is the auto-merge setting of a pull request, the pull request is merged once its requirements are met
+type AutoMerge struct {
+	EnabledBy     User        `json:"enabled_by"`
+	MergeMethod   MergeMethod `json:"merge_method"`
+	CommitTitle   string      `json:"commit_title"`
+	CommitMessage string      `json:"commit_message"`
+}
+
+// CommitFile is a file changed by a pull request or commit
+type CommitFile struct {
+	Sha         string     `json:"sha"`
+	Filename    string     `json:"filename"`
+	Status      FileStatus `json:"status"`
+	Additions   int        `json:"additions"`
+	Deletions   int        `json:"deletions"`
+	Changes     int        `json:"changes"`
+	BlobURL     string     `json:"blob_url"`
+	RawURL      string     `json:"raw_url"`
+	ContentsURL string     `json:"contents_url"`
+	// Patch is the unified diff of the file, nil for binary files and very large diffs
+	Patch            *string `json:"patch"`
+	PreviousFilename *string `json:"previous_filename"`
+}
+
+// Review is a pull request review
+type Review struct {
+	ID                int        `json:"id"`
+	NodeID            string     `json:"node_id"`
+	User              *User      `json:"user"`
+	Body              string     `json:"body"`
+	State             string     `json:"state"`
+	CommitID          string     `json:"commit_id"`
+	HTMLURL           string     `json:"html_url"`
+	PullRequestURL    string     `json:"pull_request_url"`
+	AuthorAssociation string     `json:"author_association"`
+	SubmittedAt       *Timestamp `json:"submitted_at"`
+}
+
+// PullRequestComment is a review comment on a line of a pull request diff
+type PullRequestComment struct {
+	ID                  int       `json:"id"`
+	NodeID              string    `json:"node_id"`
+	PullRequestReviewID *int      `json:"pull_request_review_id"`
+	DiffHunk            string    `json:"diff_hunk"`
+	Path                string    `json:"path"`
+	Line                *int      `json:"line"`
+	StartLine           *int      `json:"start_line"`
+	Side                string    `json:"side"`
+	StartSide           *string   `json:"start_side"`
+	CommitID            string    `json:"commit_id"`
+	OriginalCommitID    string    `json:"original_commit_id"`
+	InReplyToID         *int      `json:"in_reply_to_id"`
+	User                User      `json:"user"`
+	Body                string    `json:"body"`
+	CreatedAt           Timestamp `json:"created_at"`
+	UpdatedAt           Timestamp `json:"updated_at"`
+	HTMLURL             string    `json:"html_url"`
+	URL                 string    `json:"url"`
+	PullRequestURL      string    `json:"pull_request_url"`
+	AuthorAssociation   string    `json:"author_association"`
+}
+
+// PullRequestMergeResult is the outcome of merging a pull request
+type PullRequestMergeResult struct {
+	Sha     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+// Label provides details about a label applied to an issue or pull request
+type Label struct {
+	ID          int     `json:"id"`
+	NodeID      string  `json:"node_id"`
+	URL         string  `json:"url"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Color       string  `json:"color"`
+	Default     bool    `json:"default"`
+}
+
+// Team provides details about an organization team
+type Team struct {
+	ID                  int     `json:"id"`
+	NodeID              string  `json:"node_id"`
+	Name                string  `json:"name"`
+	Slug                string  `json:"slug"`
+	Description         *string `json:"description"`
+	Privacy             string  `json:"privacy"`
+	NotificationSetting string  `json:"notification_setting"`
+	Permission          string  `json:"permission"`
+	URL                 string  `json:"url"`
+	HTMLURL             string  `json:"html_url"`
+	MembersURL          string  `json:"members_url"`
+	RepositoriesURL     string  `json:"repositories_url"`
+	Parent              *Team   `json:"parent"`
+}
+
+// Milestone provides details about the milestone an issue or pull request is tracked against
+type Milestone struct {
+	URL          string     `json:"url"`
+	HTMLURL      string     `json:"html_url"`
+	LabelsURL    string     `json:"labels_url"`
+	ID           int        `json:"id"`
+	NodeID       string     `json:"node_id"`
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Description  *string    `json:"description"`
+	Creator      User       `json:"creator"`
+	OpenIssues   int        `json:"open_issues"`
+	ClosedIssues int        `json:"closed_issues"`
+	State        string     `json:"state"`
+	CreatedAt    Timestamp  `json:"created_at"`
+	UpdatedAt    Timestamp  `json:"updated_at"`
+	DueOn        *Timestamp `json:"due_on"`
+	ClosedAt     *Timestamp `json:"closed_at"`
 }
 
 // Links to related data for Pull Request
@@ -378,129 +736,92 @@ type Head struct {
 	Repo  Repo   `json:"repo"`
 }
 
-// User details about the authticated person making the request
-type User struct {
-	Login             string `json:"login"`
-	ID                int    `json:"id"`
-	NodeID            string `json:"node_id"`
-	AvatarURL         string `json:"avatar_url"`
-	GravatarID        string `json:"gravatar_id"`
-	URL               string `json:"url"`
-	HTMLURL           string `json:"html_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReposURL          string `json:"repos_url"`
-	EventsURL         string `json:"events_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	Type              string `json:"type"`
-	SiteAdmin         bool   `json:"site_admin"`
-}
+// User details about the authenticated person making the request
+type User = Account
 
 // Repo contains the details about a specific repository
-type Repo struct {
-	ID               int         `json:"id"`
-	NodeID           string      `json:"node_id"`
-	Name             string      `json:"name"`
-	FullName         string      `json:"full_name"`
-	Owner            Owner       `json:"owner"`
-	Private          bool        `json:"private"`
-	HTMLURL          string      `json:"html_url"`
-	Description      interface{} `json:"description"`
-	Fork             bool        `json:"fork"`
-	URL              string      `json:"url"`
-	ForksURL         string      `json:"forks_url"`
-	KeysURL          string      `json:"keys_url"`
-	CollaboratorsURL string      `json:"collaborators_url"`
-	TeamsURL         string      `json:"teams_url"`
-	HooksURL         string      `json:"hooks_url"`
-	IssueEventsURL   string      `json:"issue_events_url"`
-	EventsURL        string      `json:"events_url"`
-	AssigneesURL     string      `json:"assignees_url"`
-	BranchesURL      string      `json:"branches_url"`
-	TagsURL          string      `json:"tags_url"`
-	BlobsURL         string      `json:"blobs_url"`
-	GitTagsURL       string      `json:"git_tags_url"`
-	GitRefsURL       string      `json:"git_refs_url"`
-	TreesURL         string      `json:"trees_url"`
-	StatusesURL      string      `json:"statuses_url"`
-	LanguagesURL     string      `json:"languages_url"`
-	StargazersURL    string      `json:"stargazers_url"`
-	ContributorsURL  string      `json:"contributors_url"`
-	SubscribersURL   string      `json:"subscribers_url"`
-	SubscriptionURL  string      `json:"subscription_url"`
-	CommitsURL       string      `json:"commits_url"`
-	GitCommitsURL    string      `json:"git_commits_url"`
-	CommentsURL      string      `json:"comments_url"`
-	IssueCommentURL  string      `json:"issue_comment_url"`
-	ContentsURL      string      `json:"contents_url"`
-	CompareURL       string      `json:"compare_url"`
-	MergesURL        string      `json:"merges_url"`
-	ArchiveURL       string      `json:"archive_url"`
-	DownloadsURL     string      `json:"downloads_url"`
-	IssuesURL        string      `json:"issues_url"`
-	PullsURL         string      `json:"pulls_url"`
-	MilestonesURL    string      `json:"milestones_url"`
-	NotificationsURL string      `json:"notifications_url"`
-	LabelsURL        string      `json:"labels_url"`
-	ReleasesURL      string      `json:"releases_url"`
-	DeploymentsURL   string      `json:"deployments_url"`
-	CreatedAt        time.Time   `json:"created_at"`
-	UpdatedAt        time.Time   `json:"updated_at"`
-	PushedAt         time.Time   `json:"pushed_at"`
-	GitURL           string      `json:"git_url"`
-	SSHURL           string      `json:"ssh_url"`
-	CloneURL         string      `json:"clone_url"`
-	SvnURL           string      `json:"svn_url"`
-	Homepage         interface{} `json:"homepage"`
-	Size             int         `json:"size"`
-	StargazersCount  int         `json:"stargazers_count"`
-	WatchersCount    int         `json:"watchers_count"`
-	Language         interface{} `json:"language"`
-	HasIssues        bool        `json:"has_issues"`
-	HasProjects      bool        `json:"has_projects"`
-	HasDownloads     bool        `json:"has_downloads"`
-	HasWiki          bool        `json:"has_wiki"`
-	HasPages         bool        `json:"has_pages"`
-	ForksCount       int         `json:"forks_count"`
-	MirrorURL        interface{} `json:"mirror_url"`
-	Archived         bool        `json:"archived"`
-	OpenIssuesCount  int         `json:"open_issues_count"`
-	License          interface{} `json:"license"`
-	Forks            int         `json:"forks"`
-	OpenIssues       int         `json:"open_issues"`
-	Watchers         int         `json:"watchers"`
-	DefaultBranch    string      `json:"default_branch"`
-}
-
-// Permissions provides permission details a specific installation
+type Repo = Repository
+
+// Permissions provides permission details a specific installation, scopes the app was not granted are empty
 type Permissions struct {
-	Metadata string `json:"metadata"`
-	Contents string `json:"contents"`
-	Issues   string `json:"issues"`
+	Actions                                 PermissionLevel `json:"actions,omitempty"`
+	Administration                          PermissionLevel `json:"administration,omitempty"`
+	Checks                                  PermissionLevel `json:"checks,omitempty"`
+	Codespaces                              PermissionLevel `json:"codespaces,omitempty"`
+	Contents                                PermissionLevel `json:"contents,omitempty"`
+	DependabotSecrets                       PermissionLevel `json:"dependabot_secrets,omitempty"`
+	Deployments                             PermissionLevel `json:"deployments,omitempty"`
+	Discussions                             PermissionLevel `json:"discussions,omitempty"`
+	Environments                            PermissionLevel `json:"environments,omitempty"`
+	Issues                                  PermissionLevel `json:"issues,omitempty"`
+	MergeQueues                             PermissionLevel `json:"merge_queues,omitempty"`
+	Metadata                                PermissionLevel `json:"metadata,omitempty"`
+	Packages                                PermissionLevel `json:"packages,omitempty"`
+	Pages                                   PermissionLevel `json:"pages,omitempty"`
+	PullRequests                            PermissionLevel `json:"pull_requests,omitempty"`
+	RepositoryCustomProperties              PermissionLevel `json:"repository_custom_properties,omitempty"`
+	RepositoryHooks                         PermissionLevel `json:"repository_hooks,omitempty"`
+	RepositoryProjects                      PermissionLevel `json:"repository_projects,omitempty"`
+	SecretScanningAlerts                    PermissionLevel `json:"secret_scanning_alerts,omitempty"`
+	Secrets                                 PermissionLevel `json:"secrets,omitempty"`
+	SecurityEvents                          PermissionLevel `json:"security_events,omitempty"`
+	SingleFile                              PermissionLevel `json:"single_file,omitempty"`
+	Statuses                                PermissionLevel `json:"statuses,omitempty"`
+	VulnerabilityAlerts                     PermissionLevel `json:"vulnerability_alerts,omitempty"`
+	Workflows                               PermissionLevel `json:"workflows,omitempty"`
+	Members                                 PermissionLevel `json:"members,omitempty"`
+	OrganizationAdministration              PermissionLevel `json:"organization_administration,omitempty"`
+	OrganizationAnnouncementBanners         PermissionLevel `json:"organization_announcement_banners,omitempty"`
+	OrganizationCustomOrgRoles              PermissionLevel `json:"organization_custom_org_roles,omitempty"`
+	OrganizationCustomProperties            PermissionLevel `json:"organization_custom_properties,omitempty"`
+	OrganizationCustomRoles                 PermissionLevel `json:"organization_custom_roles,omitempty"`
+	OrganizationCopilotSeatManagement       PermissionLevel `json:"organization_copilot_seat_management,omitempty"`
+	OrganizationEvents                      PermissionLevel `json:"organization_events,omitempty"`
+	OrganizationHooks                       PermissionLevel `json:"organization_hooks,omitempty"`
+	OrganizationPackages                    PermissionLevel `json:"organization_packages,omitempty"`
+	OrganizationPersonalAccessTokenRequests PermissionLevel `json:"organization_personal_access_token_requests,omitempty"`
+	OrganizationPersonalAccessTokens        PermissionLevel `json:"organization_personal_access_tokens,omitempty"`
+	OrganizationPlan                        PermissionLevel `json:"organization_plan,omitempty"`
+	OrganizationProjects                    PermissionLevel `json:"organization_projects,omitempty"`
+	OrganizationSecrets                     PermissionLevel `json:"organization_secrets,omitempty"`
+	OrganizationSelfHostedRunners           PermissionLevel `json:"organization_self_hosted_runners,omitempty"`
+	OrganizationUserBlocking                PermissionLevel `json:"organization_user_blocking,omitempty"`
+	TeamDiscussions                         PermissionLevel `json:"team_discussions,omitempty"`
+	EmailAddresses                          PermissionLevel `json:"email_addresses,omitempty"`
+	Followers                               PermissionLevel `json:"followers,omitempty"`
+	GitSSHKeys                              PermissionLevel `json:"git_ssh_keys,omitempty"`
+	GPGKeys                                 PermissionLevel `json:"gpg_keys,omitempty"`
+	InteractionLimits                       PermissionLevel `json:"interaction_limits,omitempty"`
+	Profile                                 PermissionLevel `json:"profile,omitempty"`
+	Starring                                PermissionLevel `json:"starring,omitempty"`
 }
 
-// Account provides details about a specific account where the app is installed
+// Account provides details about a user, bot or organization acting on GitHub, every user-like object in a payload decodes into it
 type Account struct {
-	Login             string `json:"login"`
-	ID                int    `json:"id"`
-	NodeID            string `json:"node_id"`
-	AvatarURL         string `json:"avatar_url"`
-	GravatarID        string `json:"gravatar_id"`
-	URL               string `json:"url"`
-	HTMLURL           string `json:"html_url"`
-	FollowersURL      string `json:"followers_url"`
-	FollowingURL      string `json:"following_url"`
-	GistsURL          string `json:"gists_url"`
-	StarredURL        string `json:"starred_url"`
-	SubscriptionsURL  string `json:"subscriptions_url"`
-	OrganizationsURL  string `json:"organizations_url"`
-	ReposURL          string `json:"repos_url"`
-	EventsURL         string `json:"events_url"`
-	ReceivedEventsURL string `json:"received_events_url"`
-	Type              string `json:"type"`
-	SiteAdmin         bool   `json:"site_admin"`
+	Login             string      `json:"login"`
+	ID                int         `json:"id"`
+	NodeID            string      `json:"node_id"`
+	Name              *string     `json:"name"`
+	Email             *string     `json:"email"`
+	AvatarURL         string      `json:"avatar_url"`
+	GravatarID        string      `json:"gravatar_id"`
+	URL               string      `json:"url"`
+	HTMLURL           string      `json:"html_url"`
+	FollowersURL      string      `json:"followers_url"`
+	FollowingURL      string      `json:"following_url"`
+	GistsURL          string      `json:"gists_url"`
+	StarredURL        string      `json:"starred_url"`
+	SubscriptionsURL  string      `json:"subscriptions_url"`
+	OrganizationsURL  string      `json:"organizations_url"`
+	ReposURL          string      `json:"repos_url"`
+	EventsURL         string      `json:"events_url"`
+	ReceivedEventsURL string      `json:"received_events_url"`
+	HooksURL          string      `json:"hooks_url"`
+	IssuesURL         string      `json:"issues_url"`
+	MembersURL        string      `json:"members_url"`
+	PublicMembersURL  string      `json:"public_members_url"`
+	Description       *string     `json:"description"`
+	Type              AccountType `json:"type"`
+	UserViewType      string      `json:"user_view_type"`
+	SiteAdmin         bool        `json:"site_admin"`
 }
@@ -0,0 +1,112 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNullableDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    Nullable[bool]
+		encoded string
+	}{
+		{"absent", `{}`, Nullable[bool]{}, `null`},
+		{"null", `{"mergeable":null}`, Nullable[bool]{Set: true}, `null`},
+		{"false", `{"mergeable":false}`, Nullable[bool]{Value: false, Valid: true, Set: true}, `false`},
+		{"true", `{"mergeable":true}`, Nullable[bool]{Value: true, Valid: true, Set: true}, `true`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pr PullRequest
+			if err := json.Unmarshal([]byte(tt.payload), &pr); err != nil {
+				t.Fatal(err)
+			}
+			if pr.Mergeable != tt.want {
+				t.Errorf("Mergeable = %+v, want %+v", pr.Mergeable, tt.want)
+			}
+
+			b, err := json.Marshal(pr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(b, &fields); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(fields["mergeable"]); got != tt.encoded {
+				t.Errorf("re-encoded mergeable = %s, want %s", got, tt.encoded)
+			}
+		})
+	}
+}
+
+func TestNullableHelpers(t *testing.T) {
+	if v, ok := NullableOf(true).Get(); !v || !ok {
+		t.Errorf("NullableOf(true).Get() = %v, %v", v, ok)
+	}
+	if n := Null[bool](); n.Valid || !n.Set || n.Ptr() != nil {
+		t.Errorf("Null() = %+v", n)
+	}
+	if p := NullableOf(3).Ptr(); p == nil || *p != 3 {
+		t.Errorf("NullableOf(3).Ptr() = %v", p)
+	}
+}
+
+func TestPointerFieldsDecode(t *testing.T) {
+	completed := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+	tests := []struct {
+		name        string
+		repo        string
+		run         string
+		description *string
+		completedAt *Timestamp
+	}{
+		// pointers cannot tell an absent field from null, both decode to nil
+		{"absent", `{}`, `{}`, nil, nil},
+		{"null", `{"description":null}`, `{"completed_at":null}`, nil, nil},
+		{"value", `{"description":"tools"}`, `{"completed_at":"2023-04-05T06:07:08Z"}`, Ptr("tools"), &Timestamp{completed}},
+		{"empty", `{"description":""}`, `{"completed_at":1680674828}`, Ptr(""), &Timestamp{completed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var repo Repository
+			if err := json.Unmarshal([]byte(tt.repo), &repo); err != nil {
+				t.Fatal(err)
+			}
+			if !equalPtr(repo.Description, tt.description, func(a, b string) bool { return a == b }) {
+				t.Errorf("Description = %v, want %v", repo.Description, tt.description)
+			}
+
+			var run CheckRun
+			if err := json.Unmarshal([]byte(tt.run), &run); err != nil {
+				t.Fatal(err)
+			}
+			if !equalPtr(run.CompletedAt, tt.completedAt, Timestamp.Equal) {
+				t.Errorf("CompletedAt = %v, want %v", run.CompletedAt, tt.completedAt)
+			}
+
+			// re-encoding keeps the value, and turns both absent and null into null
+			b, err := json.Marshal(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var again Repository
+			if err := json.Unmarshal(b, &again); err != nil {
+				t.Fatal(err)
+			}
+			if !equalPtr(again.Description, tt.description, func(a, b string) bool { return a == b }) {
+				t.Errorf("re-decoded Description = %v, want %v", again.Description, tt.description)
+			}
+		})
+	}
+}
+
+func equalPtr[T any](a, b *T, eq func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return eq(*a, *b)
+}
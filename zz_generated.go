@@ -0,0 +1,324 @@
+// Code generated by ghgen from the octokit/webhooks schemas. DO NOT EDIT.
+
+package ghclient
+
+// CommitCommentAction is the action of a commit_comment event
+type CommitCommentAction string
+
+// Values of CommitCommentAction
+const (
+	CommitCommentActionCreated CommitCommentAction = "created"
+)
+
+// IsValid reports whether the value is one the schema defines
+func (v CommitCommentAction) IsValid() bool {
+	switch v {
+	case CommitCommentActionCreated:
+		return true
+	}
+	return false
+}
+
+// CommitCommentEvent is sent for the commit_comment webhook.
+type CommitCommentEvent struct {
+	Payload
+
+	Action       CommitCommentAction `json:"action"`
+	Comment      CommitComment       `json:"comment"`
+	Installation Installation        `json:"installation"`
+	Organization Organization        `json:"organization"`
+	Repository   Repository          `json:"repository"`
+	Sender       Account             `json:"sender"`
+}
+
+// CommitComment is the [commit comment](https://docs.github.com/en/rest/commits/comments#get-a-commit-comment) resource.
+type CommitComment struct {
+	AuthorAssociation AuthorAssociation `json:"author_association"`
+	Body              string            `json:"body"`
+	CommitID          string            `json:"commit_id"`
+	CreatedAt         Timestamp         `json:"created_at"`
+	HTMLURL           string            `json:"html_url"`
+	ID                int               `json:"id"`
+	Line              *int              `json:"line"`
+	NodeID            string            `json:"node_id"`
+	Path              *string           `json:"path"`
+	Position          *int              `json:"position"`
+	Reactions         Reactions         `json:"reactions"`
+	UpdatedAt         Timestamp         `json:"updated_at"`
+	URL               string            `json:"url"`
+	User              Account           `json:"user"`
+}
+
+// AuthorAssociation is the shared common/author_association.schema.json schema. How the author is associated with the repository.
+type AuthorAssociation string
+
+// Values of AuthorAssociation
+const (
+	AuthorAssociationCollaborator         AuthorAssociation = "COLLABORATOR"
+	AuthorAssociationContributor          AuthorAssociation = "CONTRIBUTOR"
+	AuthorAssociationFirstTimer           AuthorAssociation = "FIRST_TIMER"
+	AuthorAssociationFirstTimeContributor AuthorAssociation = "FIRST_TIME_CONTRIBUTOR"
+	AuthorAssociationMannequin            AuthorAssociation = "MANNEQUIN"
+	AuthorAssociationMember               AuthorAssociation = "MEMBER"
+	AuthorAssociationNone                 AuthorAssociation = "NONE"
+	AuthorAssociationOwner                AuthorAssociation = "OWNER"
+)
+
+// IsValid reports whether the value is one the schema defines
+func (v AuthorAssociation) IsValid() bool {
+	switch v {
+	case AuthorAssociationCollaborator, AuthorAssociationContributor, AuthorAssociationFirstTimer, AuthorAssociationFirstTimeContributor, AuthorAssociationMannequin, AuthorAssociationMember, AuthorAssociationNone, AuthorAssociationOwner:
+		return true
+	}
+	return false
+}
+
+// Reactions is the shared common/reactions.schema.json schema.
+type Reactions struct {
+	PlusOne    int    `json:"+1"`
+	MinusOne   int    `json:"-1"`
+	Confused   int    `json:"confused"`
+	Eyes       int    `json:"eyes"`
+	Heart      int    `json:"heart"`
+	Hooray     int    `json:"hooray"`
+	Laugh      int    `json:"laugh"`
+	Rocket     int    `json:"rocket"`
+	TotalCount int    `json:"total_count"`
+	URL        string `json:"url"`
+}
+
+// GollumEvent is sent for the gollum webhook.
+type GollumEvent struct {
+	Payload
+
+	Installation Installation `json:"installation"`
+	Organization Organization `json:"organization"`
+	Pages        []WikiPage   `json:"pages"`
+	Repository   Repository   `json:"repository"`
+	Sender       Account      `json:"sender"`
+}
+
+// WikiPage is an item of the pages field of GollumEvent.
+type WikiPage struct {
+	Action   WikiPageAction `json:"action"`
+	HTMLURL  string         `json:"html_url"`
+	PageName string         `json:"page_name"`
+	Sha      string         `json:"sha"`
+	Summary  *string        `json:"summary"`
+	Title    string         `json:"title"`
+}
+
+// WikiPageAction is the action that was performed on the page.
+type WikiPageAction string
+
+// Values of WikiPageAction
+const (
+	WikiPageActionCreated WikiPageAction = "created"
+	WikiPageActionEdited  WikiPageAction = "edited"
+)
+
+// IsValid reports whether the value is one the schema defines
+func (v WikiPageAction) IsValid() bool {
+	switch v {
+	case WikiPageActionCreated, WikiPageActionEdited:
+		return true
+	}
+	return false
+}
+
+// MergeGroupAction is the action of a merge_group event
+type MergeGroupAction string
+
+// Values of MergeGroupAction
+const (
+	MergeGroupActionChecksRequested MergeGroupAction = "checks_requested"
+	MergeGroupActionDestroyed       MergeGroupAction = "destroyed"
+)
+
+// IsValid reports whether the value is one the schema defines
+func (v MergeGroupAction) IsValid() bool {
+	switch v {
+	case MergeGroupActionChecksRequested, MergeGroupActionDestroyed:
+		return true
+	}
+	return false
+}
+
+// MergeGroupEvent is sent for the merge_group webhook.
+type MergeGroupEvent struct {
+	Payload
+
+	Action       MergeGroupAction `json:"action"`
+	Installation Installation     `json:"installation"`
+	MergeGroup   MergeGroup       `json:"merge_group"`
+	Organization Organization     `json:"organization"`
+	Reason       MergeGroupReason `json:"reason"`
+	Repository   Repository       `json:"repository"`
+	Sender       Account          `json:"sender"`
+}
+
+// MergeGroup is the merge_group field of MergeGroupEvent.
+type MergeGroup struct {
+	BaseRef    string     `json:"base_ref"`
+	BaseSha    string     `json:"base_sha"`
+	HeadCommit HeadCommit `json:"head_commit"`
+	HeadRef    string     `json:"head_ref"`
+	HeadSha    string     `json:"head_sha"`
+}
+
+// MergeGroupReason is the reason field of MergeGroupEvent. Explains why the merge group is being destroyed.
+type MergeGroupReason string
+
+// Values of MergeGroupReason
+const (
+	MergeGroupReasonMerged      MergeGroupReason = "merged"
+	MergeGroupReasonInvalidated MergeGroupReason = "invalidated"
+	MergeGroupReasonDequeued    MergeGroupReason = "dequeued"
+)
+
+// IsValid reports whether the value is one the schema defines
+func (v MergeGroupReason) IsValid() bool {
+	switch v {
+	case MergeGroupReasonMerged, MergeGroupReasonInvalidated, MergeGroupReasonDequeued:
+		return true
+	}
+	return false
+}
+
+// HeadCommit is the shared common/simple-commit.schema.json schema.
+type HeadCommit struct {
+	Author    GitActor  `json:"author"`
+	Committer GitActor  `json:"committer"`
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	Timestamp Timestamp `json:"timestamp"`
+	TreeID    string    `json:"tree_id"`
+}
+
+// GitActor is the shared common/committer.schema.json schema. Metaproperties for Git author/committer information.
+type GitActor struct {
+	Date     Timestamp `json:"date"`
+	Email    *string   `json:"email"`
+	Name     string    `json:"name"`
+	Username string    `json:"username"`
+}
+
+// PageBuildEvent is sent for the page_build webhook.
+type PageBuildEvent struct {
+	Payload
+
+	Build        PageBuild    `json:"build"`
+	ID           int          `json:"id"`
+	Installation Installation `json:"installation"`
+	Organization Organization `json:"organization"`
+	Repository   Repository   `json:"repository"`
+	Sender       Account      `json:"sender"`
+}
+
+// PageBuild is the [List GitHub Pages builds](https://docs.github.com/en/rest/pages#list-github-pages-builds) itself.
+type PageBuild struct {
+	Commit    string         `json:"commit"`
+	CreatedAt Timestamp      `json:"created_at"`
+	Duration  int            `json:"duration"`
+	Error     PageBuildError `json:"error"`
+	Pusher    *Account       `json:"pusher"`
+	Status    string         `json:"status"`
+	UpdatedAt Timestamp      `json:"updated_at"`
+	URL       string         `json:"url"`
+}
+
+// PageBuildError is the error field of PageBuild.
+type PageBuildError struct {
+	Message *string `json:"message"`
+}
+
+// StatusEvent is sent for the status webhook.
+type StatusEvent struct {
+	Payload
+
+	AvatarURL    *string        `json:"avatar_url"`
+	Branches     []StatusBranch `json:"branches"`
+	Commit       StatusCommit   `json:"commit"`
+	Context      string         `json:"context"`
+	CreatedAt    Timestamp      `json:"created_at"`
+	Description  *string        `json:"description"`
+	ID           int            `json:"id"`
+	Installation Installation   `json:"installation"`
+	Name         string         `json:"name"`
+	Organization Organization   `json:"organization"`
+	Repository   Repository     `json:"repository"`
+	Sender       Account        `json:"sender"`
+	Sha          string         `json:"sha"`
+	State        StatusState    `json:"state"`
+	TargetURL    *string        `json:"target_url"`
+	UpdatedAt    Timestamp      `json:"updated_at"`
+}
+
+// StatusBranch is an item of the branches field of StatusEvent.
+type StatusBranch struct {
+	Commit    BranchCommit `json:"commit"`
+	Name      string       `json:"name"`
+	Protected bool         `json:"protected"`
+}
+
+// StatusCommit is the commit field of StatusEvent.
+type StatusCommit struct {
+	Author      *Account     `json:"author"`
+	CommentsURL string       `json:"comments_url"`
+	Commit      GitCommit    `json:"commit"`
+	Committer   *Account     `json:"committer"`
+	HTMLURL     string       `json:"html_url"`
+	NodeID      string       `json:"node_id"`
+	Parents     []CommitLink `json:"parents"`
+	Sha         string       `json:"sha"`
+	URL         string       `json:"url"`
+}
+
+// StatusState is the new state.
+type StatusState string
+
+// Values of StatusState
+const (
+	StatusStatePending StatusState = "pending"
+	StatusStateSuccess StatusState = "success"
+	StatusStateFailure StatusState = "failure"
+	StatusStateError   StatusState = "error"
+)
+
+// IsValid reports whether the value is one the schema defines
+func (v StatusState) IsValid() bool {
+	switch v {
+	case StatusStatePending, StatusStateSuccess, StatusStateFailure, StatusStateError:
+		return true
+	}
+	return false
+}
+
+// BranchCommit is the commit field of StatusBranch.
+type BranchCommit struct {
+	Sha *string `json:"sha"`
+	URL *string `json:"url"`
+}
+
+// GitCommit is the commit field of StatusCommit.
+type GitCommit struct {
+	Author       GitActor      `json:"author"`
+	CommentCount int           `json:"comment_count"`
+	Committer    GitActor      `json:"committer"`
+	Message      string        `json:"message"`
+	Tree         GitCommitTree `json:"tree"`
+	URL          string        `json:"url"`
+}
+
+// CommitLink is an item of the parents field of StatusCommit.
+type CommitLink struct {
+	HTMLURL string `json:"html_url"`
+	Sha     string `json:"sha"`
+	URL     string `json:"url"`
+}
+
+// GitCommitTree is the tree field of GitCommit.
+type GitCommitTree struct {
+	Sha string `json:"sha"`
+	URL string `json:"url"`
+}
@@ -0,0 +1,33 @@
+// Code generated by ghgen from the octokit/webhooks schemas. DO NOT EDIT.
+
+package ghclient
+
+import (
+	"os"
+	"testing"
+)
+
+func TestGeneratedEventExamples(t *testing.T) {
+	tests := []struct {
+		example string
+		event   func() interface{}
+	}{
+		{"schemas/examples/api.github.com/commit_comment/created.payload.json", func() interface{} { return &CommitCommentEvent{} }},
+		{"schemas/examples/api.github.com/gollum/event.payload.json", func() interface{} { return &GollumEvent{} }},
+		{"schemas/examples/api.github.com/merge_group/checks_requested.payload.json", func() interface{} { return &MergeGroupEvent{} }},
+		{"schemas/examples/api.github.com/merge_group/destroyed.payload.json", func() interface{} { return &MergeGroupEvent{} }},
+		{"schemas/examples/api.github.com/page_build/event.payload.json", func() interface{} { return &PageBuildEvent{} }},
+		{"schemas/examples/api.github.com/status/event.payload.json", func() interface{} { return &StatusEvent{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.example, func(t *testing.T) {
+			payload, err := os.ReadFile(tt.example)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Decode(payload, tt.event(), DisallowUnknownFields(), DisallowUnknownActions()); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}